@@ -0,0 +1,366 @@
+// package btree implements an ordered map on a B-tree. Every node keeps
+// its items in one slice, so lookups and scans touch few cache lines.
+package btree
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// DefaultDegree is the minimum degree used by New when degree is not positive.
+const DefaultDegree = 32
+
+type item[K, V any] struct {
+	key   K
+	value V
+}
+
+// node holds between degree-1 and 2*degree-1 items, the root may hold fewer.
+// An inner node has one more child than items, a leaf has no children.
+type node[K, V any] struct {
+	items    []item[K, V]
+	children []*node[K, V]
+}
+
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// BTree is an ordered map. The zero value is not usable, create one with
+// New or NewFunc. A BTree is not safe for concurrent use.
+type BTree[K, V any] struct {
+	root   *node[K, V]
+	degree int
+	len    int
+	cmp    func(a, b K) int
+}
+
+// New returns an empty B-tree ordered by cmp.Compare whose nodes hold up to
+// 2*degree-1 items.
+func New[K cmp.Ordered, V any](degree int) *BTree[K, V] {
+	return NewFunc[K, V](cmp.Compare[K], degree)
+}
+
+// NewFunc is New with keys ordered by cmp.
+func NewFunc[K, V any](cmp func(a, b K) int, degree int) *BTree[K, V] {
+	if degree < 2 {
+		degree = DefaultDegree
+	}
+	return &BTree[K, V]{degree: degree, cmp: cmp}
+}
+
+func (t *BTree[K, V]) maxItems() int {
+	return 2*t.degree - 1
+}
+
+// search returns the index of the first item not less than key and
+// whether that item equals key.
+func (t *BTree[K, V]) search(n *node[K, V], key K) (int, bool) {
+	return slices.BinarySearchFunc(n.items, key, func(it item[K, V], key K) int {
+		return t.cmp(it.key, key)
+	})
+}
+
+// Len returns the number of keys.
+func (t *BTree[K, V]) Len() int {
+	return t.len
+}
+
+// Put associates value with key and reports whether key was already present.
+func (t *BTree[K, V]) Put(key K, value V) bool {
+	if t.root == nil {
+		t.root = &node[K, V]{items: []item[K, V]{{key, value}}}
+		t.len = 1
+		return false
+	}
+	if len(t.root.items) == t.maxItems() {
+		t.root = &node[K, V]{children: []*node[K, V]{t.root}}
+		t.splitChild(t.root, 0)
+	}
+	replaced := t.insert(t.root, key, value)
+	if !replaced {
+		t.len++
+	}
+	return replaced
+}
+
+// splitChild splits the full child i of n around its median item,
+// which moves up into n.
+func (t *BTree[K, V]) splitChild(n *node[K, V], i int) {
+	child := n.children[i]
+	mid := t.degree - 1
+	median := child.items[mid]
+	right := &node[K, V]{items: slices.Clone(child.items[mid+1:])}
+	clear(child.items[mid:])
+	child.items = child.items[:mid]
+	if !child.leaf() {
+		right.children = slices.Clone(child.children[mid+1:])
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+	n.items = slices.Insert(n.items, i, median)
+	n.children = slices.Insert(n.children, i+1, right)
+}
+
+// insert puts key into the subtree of n, which is not full.
+func (t *BTree[K, V]) insert(n *node[K, V], key K, value V) bool {
+	for {
+		i, found := t.search(n, key)
+		if found {
+			n.items[i].value = value
+			return true
+		}
+		if n.leaf() {
+			n.items = slices.Insert(n.items, i, item[K, V]{key, value})
+			return false
+		}
+		if len(n.children[i].items) == t.maxItems() {
+			t.splitChild(n, i)
+			switch c := t.cmp(key, n.items[i].key); {
+			case c == 0:
+				n.items[i].value = value
+				return true
+			case c > 0:
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// Get returns the value associated with key and whether it was found.
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	for n := t.root; n != nil; {
+		i, found := t.search(n, key)
+		if found {
+			return n.items[i].value, true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	var v V
+	return v, false
+}
+
+// Contains reports whether key is present.
+func (t *BTree[K, V]) Contains(key K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Delete removes key and reports whether it was present.
+func (t *BTree[K, V]) Delete(key K) bool {
+	if t.root == nil {
+		return false
+	}
+	found := t.delete(t.root, key)
+	if len(t.root.items) == 0 {
+		if t.root.leaf() {
+			t.root = nil
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	if found {
+		t.len--
+	}
+	return found
+}
+
+// delete removes key from the subtree of n. Every node it descends into is
+// first given at least degree items, so removing one never underflows it.
+func (t *BTree[K, V]) delete(n *node[K, V], key K) bool {
+	for {
+		i, found := t.search(n, key)
+		if n.leaf() {
+			if found {
+				n.items = slices.Delete(n.items, i, i+1)
+			}
+			return found
+		}
+		if found {
+			switch {
+			case len(n.children[i].items) >= t.degree:
+				// replace the key with its predecessor and delete that instead
+				pred := n.children[i]
+				for !pred.leaf() {
+					pred = pred.children[len(pred.children)-1]
+				}
+				n.items[i] = pred.items[len(pred.items)-1]
+				n, key = n.children[i], n.items[i].key
+			case len(n.children[i+1].items) >= t.degree:
+				succ := n.children[i+1]
+				for !succ.leaf() {
+					succ = succ.children[0]
+				}
+				n.items[i] = succ.items[0]
+				n, key = n.children[i+1], n.items[i].key
+			default:
+				t.merge(n, i)
+				n = n.children[i]
+			}
+			continue
+		}
+		if len(n.children[i].items) < t.degree {
+			i = t.fill(n, i)
+		}
+		n = n.children[i]
+	}
+}
+
+// fill gives child i of n at least degree items by borrowing from a sibling
+// or merging with one, and returns the index of the child that now covers
+// the keys child i did.
+func (t *BTree[K, V]) fill(n *node[K, V], i int) int {
+	child := n.children[i]
+	switch {
+	case i > 0 && len(n.children[i-1].items) >= t.degree:
+		left := n.children[i-1]
+		child.items = slices.Insert(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = left.items[:len(left.items)-1]
+		if !left.leaf() {
+			child.children = slices.Insert(child.children, 0, left.children[len(left.children)-1])
+			left.children = left.children[:len(left.children)-1]
+		}
+	case i < len(n.items) && len(n.children[i+1].items) >= t.degree:
+		right := n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = slices.Delete(right.items, 0, 1)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = slices.Delete(right.children, 0, 1)
+		}
+	case i < len(n.items):
+		t.merge(n, i)
+	default:
+		t.merge(n, i-1)
+		i--
+	}
+	return i
+}
+
+// merge moves item i of n and child i+1 into child i.
+func (t *BTree[K, V]) merge(n *node[K, V], i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.items = append(left.items, n.items[i])
+	left.items = append(left.items, right.items...)
+	left.children = append(left.children, right.children...)
+	n.items = slices.Delete(n.items, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+}
+
+func unpack[K, V any](it *item[K, V]) (K, V, bool) {
+	if it == nil {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	return it.key, it.value, true
+}
+
+// Min returns the smallest key and its value.
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	if t.root == nil {
+		return unpack[K, V](nil)
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return unpack(&n.items[0])
+}
+
+// Max returns the largest key and its value.
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	if t.root == nil {
+		return unpack[K, V](nil)
+	}
+	n := t.root
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return unpack(&n.items[len(n.items)-1])
+}
+
+// Floor returns the greatest key less than or equal to key.
+func (t *BTree[K, V]) Floor(key K) (K, V, bool) {
+	var res *item[K, V]
+	for n := t.root; n != nil; {
+		i, found := t.search(n, key)
+		if found {
+			return unpack(&n.items[i])
+		}
+		if i > 0 {
+			res = &n.items[i-1]
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return unpack(res)
+}
+
+// Ceiling returns the least key greater than or equal to key.
+func (t *BTree[K, V]) Ceiling(key K) (K, V, bool) {
+	var res *item[K, V]
+	for n := t.root; n != nil; {
+		i, found := t.search(n, key)
+		if found {
+			return unpack(&n.items[i])
+		}
+		if i < len(n.items) {
+			res = &n.items[i]
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return unpack(res)
+}
+
+// ascend yields the items of the subtree of n that are not less than from,
+// or all of them if from is nil. It returns false once yield does.
+func (t *BTree[K, V]) ascend(n *node[K, V], from *K, yield func(K, V) bool) bool {
+	i := 0
+	if from != nil {
+		i, _ = t.search(n, *from)
+	}
+	for ; i <= len(n.items); i++ {
+		if !n.leaf() && !t.ascend(n.children[i], from, yield) {
+			return false
+		}
+		// only the subtree left of the first item can hold smaller keys
+		from = nil
+		if i < len(n.items) && !yield(n.items[i].key, n.items[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+// All returns an iterator over the pairs in ascending key order.
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root != nil {
+			t.ascend(t.root, nil, yield)
+		}
+	}
+}
+
+// Ascend returns an iterator over the pairs whose key is not less than from.
+func (t *BTree[K, V]) Ascend(from K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if t.root != nil {
+			t.ascend(t.root, &from, yield)
+		}
+	}
+}
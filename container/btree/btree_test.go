@@ -0,0 +1,109 @@
+package btree
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"golabs/container"
+	"golabs/container/containertest"
+)
+
+var _ container.OrderedMap[int, int] = (*BTree[int, int])(nil)
+
+// check verifies the B-tree invariants: item counts, ordering and that
+// every leaf sits at the same depth.
+func (t *BTree[K, V]) check() error {
+	if t.root == nil {
+		if t.len != 0 {
+			return fmt.Errorf("empty tree with len %d", t.len)
+		}
+		return nil
+	}
+	leafDepth := -1
+	cnt := 0
+	var walk func(n *node[K, V], depth int, lo, hi *K) error
+	walk = func(n *node[K, V], depth int, lo, hi *K) error {
+		if len(n.items) > t.maxItems() || (n != t.root && len(n.items) < t.degree-1) {
+			return fmt.Errorf("node with %d items", len(n.items))
+		}
+		for i, it := range n.items {
+			if (i > 0 && t.cmp(n.items[i-1].key, it.key) >= 0) ||
+				(lo != nil && t.cmp(*lo, it.key) >= 0) || (hi != nil && t.cmp(it.key, *hi) >= 0) {
+				return fmt.Errorf("key %v out of order", it.key)
+			}
+		}
+		cnt += len(n.items)
+		if n.leaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				return fmt.Errorf("leaves at depth %d and %d", leafDepth, depth)
+			}
+			return nil
+		}
+		if len(n.children) != len(n.items)+1 {
+			return fmt.Errorf("node with %d items has %d children", len(n.items), len(n.children))
+		}
+		for i, c := range n.children {
+			l, h := lo, hi
+			if i > 0 {
+				l = &n.items[i-1].key
+			}
+			if i < len(n.items) {
+				h = &n.items[i].key
+			}
+			if err := walk(c, depth+1, l, h); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(t.root, 0, nil, nil); err != nil {
+		return err
+	}
+	if cnt != t.len {
+		return fmt.Errorf("len %d; counted %d", t.len, cnt)
+	}
+	return nil
+}
+
+func TestOrderedMap(t *testing.T) {
+	for _, degree := range []int{2, 3, DefaultDegree} {
+		t.Run(fmt.Sprint("Degree", degree), func(t *testing.T) {
+			containertest.TestOrderedMap(t, func() container.OrderedMap[int, int] {
+				return New[int, int](degree)
+			})
+		})
+	}
+}
+
+func TestOrderedSet(t *testing.T) {
+	containertest.TestOrderedSet(t, func() container.OrderedSet[int] {
+		return container.SetOf(New[int, struct{}](2))
+	})
+}
+
+func TestInvariants(t *testing.T) {
+	for _, degree := range []int{2, 3, 8} {
+		tr := New[int, int](degree)
+		r := rand.New(rand.NewSource(int64(degree)))
+		for i := 0; i < 5000; i++ {
+			k := r.Intn(500)
+			if r.Intn(3) == 0 {
+				tr.Delete(k)
+			} else {
+				tr.Put(k, i)
+			}
+			if err := tr.check(); err != nil {
+				t.Fatalf("degree %d, step %d: %v", degree, i, err)
+			}
+		}
+	}
+}
+
+func BenchmarkOrderedMap(b *testing.B) {
+	containertest.BenchmarkOrderedMap(b, 100000, func() container.OrderedMap[int, int] {
+		return New[int, int](DefaultDegree)
+	})
+}
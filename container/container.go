@@ -0,0 +1,63 @@
+// package container holds the interfaces shared by the ordered containers
+// in its sub packages, so callers can swap the red-black tree, the skip list
+// and the B-tree depending on their workload.
+package container
+
+import "iter"
+
+// OrderedSet is a set of unique keys kept in ascending order.
+type OrderedSet[K any] interface {
+	// Insert adds key and reports whether it was already present.
+	Insert(key K) bool
+	// Delete removes key and reports whether it was present.
+	Delete(key K) bool
+	Contains(key K) bool
+	Len() int
+	// All iterates over the keys in ascending order.
+	All() iter.Seq[K]
+}
+
+// OrderedMap is a key/value map kept in ascending key order.
+type OrderedMap[K, V any] interface {
+	// Put associates value with key and reports whether key was already present.
+	Put(key K, value V) bool
+	Get(key K) (V, bool)
+	// Delete removes key and reports whether it was present.
+	Delete(key K) bool
+	Contains(key K) bool
+	Len() int
+	// Min, Max, Floor and Ceiling return the matching key, its value and
+	// whether there is one.
+	Min() (K, V, bool)
+	Max() (K, V, bool)
+	Floor(key K) (K, V, bool)
+	Ceiling(key K) (K, V, bool)
+	// All iterates over the pairs in ascending key order.
+	All() iter.Seq2[K, V]
+	// Ascend iterates over the pairs whose key is not less than from.
+	Ascend(from K) iter.Seq2[K, V]
+}
+
+type mapSet[K any] struct {
+	m OrderedMap[K, struct{}]
+}
+
+// SetOf returns an OrderedSet that stores its keys in m.
+func SetOf[K any](m OrderedMap[K, struct{}]) OrderedSet[K] {
+	return mapSet[K]{m}
+}
+
+func (s mapSet[K]) Insert(key K) bool   { return s.m.Put(key, struct{}{}) }
+func (s mapSet[K]) Delete(key K) bool   { return s.m.Delete(key) }
+func (s mapSet[K]) Contains(key K) bool { return s.m.Contains(key) }
+func (s mapSet[K]) Len() int            { return s.m.Len() }
+
+func (s mapSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s.m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// package containertest checks that an implementation of the container
+// interfaces behaves like the others, and benchmarks it under common workloads.
+package containertest
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"golabs/container"
+)
+
+// TestOrderedMap runs the conformance suite against the maps newMap returns.
+func TestOrderedMap(t *testing.T, newMap func() container.OrderedMap[int, int]) {
+	t.Run("Empty", func(t *testing.T) {
+		m := newMap()
+		if m.Len() != 0 || m.Contains(0) || m.Delete(0) {
+			t.Fatalf("a new map should be empty")
+		}
+		if _, ok := m.Get(0); ok {
+			t.Fatalf("Get on an empty map found a value")
+		}
+		for name, query := range map[string]func() (int, int, bool){
+			"Min": m.Min, "Max": m.Max,
+			"Floor":   func() (int, int, bool) { return m.Floor(0) },
+			"Ceiling": func() (int, int, bool) { return m.Ceiling(0) },
+		} {
+			if _, _, ok := query(); ok {
+				t.Errorf("%s on an empty map found a key", name)
+			}
+		}
+		for range m.All() {
+			t.Fatalf("All on an empty map yielded a pair")
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		m := newMap()
+		ref := make(map[int]int)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20000; i++ {
+			k := r.Intn(2000)
+			switch r.Intn(4) {
+			case 0:
+				_, want := ref[k]
+				if got := m.Delete(k); got != want {
+					t.Fatalf("Delete(%d) = %v; want %v", k, got, want)
+				}
+				delete(ref, k)
+			default:
+				_, want := ref[k]
+				if got := m.Put(k, i); got != want {
+					t.Fatalf("Put(%d) = %v; want %v", k, got, want)
+				}
+				ref[k] = i
+			}
+		}
+		checkMap(t, m, ref)
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+		m := newMap()
+		ref := make(map[int]int)
+		for i := 0; i < 5000; i++ {
+			m.Put(i, -i)
+			ref[i] = -i
+		}
+		for i := 0; i < 5000; i += 3 {
+			m.Delete(i)
+			delete(ref, i)
+		}
+		checkMap(t, m, ref)
+		for i := 4999; i >= 0; i-- {
+			m.Delete(i)
+		}
+		checkMap(t, m, map[int]int{})
+	})
+
+	t.Run("Break", func(t *testing.T) {
+		m := newMap()
+		for i := 0; i < 10; i++ {
+			m.Put(i, i)
+		}
+		var got []int
+		for k := range m.Ascend(3) {
+			if k == 6 {
+				break
+			}
+			got = append(got, k)
+		}
+		if !slices.Equal(got, []int{3, 4, 5}) {
+			t.Fatalf("Ascend(3) up to 6 = %v; want [3 4 5]", got)
+		}
+	})
+}
+
+// checkMap compares every query of m with ref.
+func checkMap(t *testing.T, m container.OrderedMap[int, int], ref map[int]int) {
+	t.Helper()
+	keys := slices.Sorted(maps.Keys(ref))
+	if m.Len() != len(keys) {
+		t.Fatalf("Len() = %d; want %d", m.Len(), len(keys))
+	}
+	var got []int
+	for k, v := range m.All() {
+		if v != ref[k] {
+			t.Fatalf("All() yields %d: %d; want %d", k, v, ref[k])
+		}
+		got = append(got, k)
+	}
+	if !slices.Equal(got, keys) {
+		t.Fatalf("All() keys = %v; want %v", got, keys)
+	}
+	for k := -1; k <= 2001; k++ {
+		v, ok := m.Get(k)
+		if want, found := ref[k]; ok != found || v != want {
+			t.Fatalf("Get(%d) = %d, %v; want %d, %v", k, v, ok, want, found)
+		}
+		if m.Contains(k) != ok {
+			t.Fatalf("Contains(%d) disagrees with Get", k)
+		}
+		at, found := slices.BinarySearch(keys, k)
+		wantFloor, wantCeil := at-1, at
+		if found {
+			wantFloor = at
+		}
+		checkQuery(t, fmt.Sprintf("Floor(%d)", k), keys, ref, wantFloor)(m.Floor(k))
+		checkQuery(t, fmt.Sprintf("Ceiling(%d)", k), keys, ref, wantCeil)(m.Ceiling(k))
+	}
+	checkQuery(t, "Min()", keys, ref, 0)(m.Min())
+	checkQuery(t, "Max()", keys, ref, len(keys)-1)(m.Max())
+	if len(keys) > 0 {
+		from := keys[len(keys)/2]
+		var asc []int
+		for k := range m.Ascend(from) {
+			asc = append(asc, k)
+		}
+		if !slices.Equal(asc, keys[len(keys)/2:]) {
+			t.Fatalf("Ascend(%d) = %v; want %v", from, asc, keys[len(keys)/2:])
+		}
+	}
+}
+
+// checkQuery returns a checker for a query that should find keys[at],
+// or nothing if at is out of range.
+func checkQuery(t *testing.T, name string, keys []int, ref map[int]int, at int) func(int, int, bool) {
+	return func(k, v int, ok bool) {
+		t.Helper()
+		if at < 0 || at >= len(keys) {
+			if ok {
+				t.Fatalf("%s = %d; want none", name, k)
+			}
+			return
+		}
+		if !ok || k != keys[at] || v != ref[k] {
+			t.Fatalf("%s = %d, %d, %v; want %d, %d, true", name, k, v, ok, keys[at], ref[keys[at]])
+		}
+	}
+}
+
+// TestOrderedSet runs the conformance suite against the sets newSet returns.
+func TestOrderedSet(t *testing.T, newSet func() container.OrderedSet[int]) {
+	s := newSet()
+	ref := make(map[int]bool)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		k := r.Intn(2000)
+		if r.Intn(3) == 0 {
+			if got := s.Delete(k); got != ref[k] {
+				t.Fatalf("Delete(%d) = %v; want %v", k, got, ref[k])
+			}
+			delete(ref, k)
+		} else {
+			if got := s.Insert(k); got != ref[k] {
+				t.Fatalf("Insert(%d) = %v; want %v", k, got, ref[k])
+			}
+			ref[k] = true
+		}
+	}
+	keys := slices.Sorted(maps.Keys(ref))
+	if got := slices.Collect(s.All()); !slices.Equal(got, keys) {
+		t.Fatalf("All() = %v; want %v", got, keys)
+	}
+	if s.Len() != len(keys) {
+		t.Fatalf("Len() = %d; want %d", s.Len(), len(keys))
+	}
+	for k := 0; k < 2000; k++ {
+		if s.Contains(k) != ref[k] {
+			t.Fatalf("Contains(%d) = %v; want %v", k, !ref[k], ref[k])
+		}
+	}
+}
+
+// BenchmarkOrderedMap measures the maps newMap returns under a few
+// workloads, n is the number of keys the map holds.
+func BenchmarkOrderedMap(b *testing.B, n int, newMap func() container.OrderedMap[int, int]) {
+	keys := rand.New(rand.NewSource(1)).Perm(n)
+	filled := func() container.OrderedMap[int, int] {
+		m := newMap()
+		for _, k := range keys {
+			m.Put(k, k)
+		}
+		return m
+	}
+	b.Run("InsertSequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i += n {
+			m := newMap()
+			for k := 0; k < n && i+k < b.N; k++ {
+				m.Put(k, k)
+			}
+		}
+	})
+	b.Run("InsertRandom", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i += n {
+			m := newMap()
+			for k := 0; k < n && i+k < b.N; k++ {
+				m.Put(keys[k], k)
+			}
+		}
+	})
+	b.Run("Get", func(b *testing.B) {
+		m := filled()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.Get(keys[i%n])
+		}
+	})
+	b.Run("Mixed", func(b *testing.B) {
+		m := filled()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			k := keys[i%n]
+			switch i % 4 {
+			case 0:
+				m.Delete(k)
+			case 1:
+				m.Put(k, i)
+			default:
+				m.Get(k)
+			}
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		m := filled()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; {
+			for range m.Ascend(keys[i%n]) {
+				if i++; i%100 == 0 {
+					break
+				}
+			}
+			i++
+		}
+	})
+}
@@ -0,0 +1,189 @@
+// package deque implements double-ended queues on circular buffers, which
+// keep their values in one slice instead of chasing list pointers.
+package deque
+
+import (
+	"fmt"
+	"iter"
+)
+
+// minCap is the smallest buffer a Deque allocates, it is a power of two.
+const minCap = 16
+
+// Deque is a double-ended queue on a growable circular buffer. Pushing and
+// popping at either end take amortized O(1) time and At takes O(1).
+// The zero value for Deque is an empty deque ready to use.
+type Deque[T any] struct {
+	// buf holds the values from buf[head] on, wrapping around at the end.
+	// Its length is zero or a power of two, so positions reduce by masking.
+	buf  []T
+	head int
+	len  int
+
+	// minBuf is the buffer size requested through New, shrink stops there.
+	minBuf int
+}
+
+// New returns an empty deque with room for at least capacity values.
+// The deque keeps that room when values are popped.
+func New[T any](capacity int) *Deque[T] {
+	d := &Deque[T]{}
+	if capacity > 0 {
+		d.minBuf = ceilPow2(capacity)
+		d.buf = make([]T, d.minBuf)
+	}
+	return d
+}
+
+func ceilPow2(n int) int {
+	c := minCap
+	for c < n {
+		c <<= 1
+	}
+	return c
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int { return d.len }
+
+// index returns the buffer position of the i-th value.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// resize moves the values to the front of a new buffer of size n.
+func (d *Deque[T]) resize(n int) {
+	buf := make([]T, n)
+	if d.len > 0 {
+		if tail := d.head + d.len; tail <= len(d.buf) {
+			copy(buf, d.buf[d.head:tail])
+		} else {
+			k := copy(buf, d.buf[d.head:])
+			copy(buf[k:], d.buf[:tail-len(d.buf)])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+func (d *Deque[T]) grow() {
+	if d.len == len(d.buf) {
+		d.resize(max(2*len(d.buf), minCap))
+	}
+}
+
+// shrink halves the buffer once it is at most a quarter full, so a deque
+// that was briefly large does not hold on to the memory. It never goes
+// below the capacity passed to New.
+func (d *Deque[T]) shrink() {
+	if len(d.buf) > max(minCap, d.minBuf) && d.len <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// PushBack adds v at the back of the deque.
+func (d *Deque[T]) PushBack(v T) {
+	d.grow()
+	d.buf[d.index(d.len)] = v
+	d.len++
+}
+
+// PushFront adds v at the front of the deque.
+func (d *Deque[T]) PushFront(v T) {
+	d.grow()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = v
+	d.len++
+}
+
+// PopFront removes and returns the front value, the bool is false if the
+// deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	v := d.buf[d.head]
+	d.buf[d.head] = zero // avoid memory leaks
+	d.head = d.index(1)
+	d.len--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns the back value, the bool is false if the
+// deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.len == 0 {
+		return zero, false
+	}
+	i := d.index(d.len - 1)
+	v := d.buf[i]
+	d.buf[i] = zero // avoid memory leaks
+	d.len--
+	d.shrink()
+	return v, true
+}
+
+// Front returns the front value, the bool is false if the deque is empty.
+func (d *Deque[T]) Front() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back value, the bool is false if the deque is empty.
+func (d *Deque[T]) Back() (T, bool) {
+	if d.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.len-1)], true
+}
+
+func checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("deque: index %d out of range [0:%d]", i, n))
+	}
+}
+
+// At returns the i-th value counted from the front.
+// It panics if i is out of range.
+func (d *Deque[T]) At(i int) T {
+	checkIndex(i, d.len)
+	return d.buf[d.index(i)]
+}
+
+// Set replaces the i-th value counted from the front.
+// It panics if i is out of range.
+func (d *Deque[T]) Set(i int, v T) {
+	checkIndex(i, d.len)
+	d.buf[d.index(i)] = v
+}
+
+// Clear removes all values and keeps the buffer.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head = 0
+	d.len = 0
+}
+
+// All returns an iterator over the positions and values from front to back.
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.len && yield(i, d.buf[d.index(i)]); i++ {
+		}
+	}
+}
+
+// Backward returns an iterator over the positions and values from back to
+// front.
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.len - 1; i >= 0 && yield(i, d.buf[d.index(i)]); i-- {
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package deque
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"golabs/container/listx"
+)
+
+func values[T any](seq func(func(int, T) bool)) []T {
+	var res []T
+	for _, v := range seq {
+		res = append(res, v)
+	}
+	return res
+}
+
+func checkDeque(t *testing.T, d *Deque[int], want []int) {
+	t.Helper()
+	if got := values(d.All()); !slices.Equal(got, want) {
+		t.Fatalf("All() = %v; want %v", got, want)
+	}
+	got := values(d.Backward())
+	slices.Reverse(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("Backward() = %v; want the reverse of %v", got, want)
+	}
+	if d.Len() != len(want) {
+		t.Fatalf("Len() = %d; want %d", d.Len(), len(want))
+	}
+	for i, v := range want {
+		if d.At(i) != v {
+			t.Fatalf("At(%d) = %d; want %d", i, d.At(i), v)
+		}
+	}
+}
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.PopFront(); ok {
+		t.Fatalf("PopFront() on an empty deque found a value")
+	}
+	if _, ok := d.Back(); ok {
+		t.Fatalf("Back() on an empty deque found a value")
+	}
+
+	var want []int
+	for i := 0; i < 5000; i++ {
+		switch rand.Intn(5) {
+		case 0:
+			v, ok := d.PopFront()
+			if ok != (len(want) > 0) || (ok && v != want[0]) {
+				t.Fatalf("PopFront() = %d, %v; want the front of %v", v, ok, want)
+			}
+			if ok {
+				want = want[1:]
+			}
+		case 1:
+			v, ok := d.PopBack()
+			if ok != (len(want) > 0) || (ok && v != want[len(want)-1]) {
+				t.Fatalf("PopBack() = %d, %v; want the back of %v", v, ok, want)
+			}
+			if ok {
+				want = want[:len(want)-1]
+			}
+		case 2, 3:
+			d.PushBack(i)
+			want = append(want, i)
+		default:
+			d.PushFront(i)
+			want = slices.Insert(want, 0, i)
+		}
+	}
+	checkDeque(t, &d, want)
+
+	if len(want) > 0 {
+		d.Set(0, -1)
+		want[0] = -1
+		if v, _ := d.Front(); v != -1 {
+			t.Errorf("Front() = %d after Set(0, -1)", v)
+		}
+	}
+	checkDeque(t, &d, want)
+
+	// draining shrinks the buffer back down
+	for d.Len() > 0 {
+		d.PopBack()
+	}
+	if len(d.buf) > minCap {
+		t.Errorf("buffer of %d after draining; want at most %d", len(d.buf), minCap)
+	}
+	d.PushBack(1)
+	d.Clear()
+	checkDeque(t, &d, nil)
+}
+
+func TestDequeKeepsCapacity(t *testing.T) {
+	d := New[int](1000)
+	for i := 0; i < 3; i++ {
+		d.PushBack(i)
+	}
+	d.PopFront()
+	if len(d.buf) < 1000 {
+		t.Errorf("buffer shrank to %d after a pop; want at least 1000", len(d.buf))
+	}
+
+	// a buffer grown past the requested capacity shrinks back down to it
+	for i := 0; i < 5000; i++ {
+		d.PushBack(i)
+	}
+	for d.Len() > 0 {
+		d.PopBack()
+	}
+	if len(d.buf) != 1024 {
+		t.Errorf("buffer shrank to %d; want 1024", len(d.buf))
+	}
+}
+
+func TestDequeOutOfRange(t *testing.T) {
+	d := New[int](0)
+	d.PushBack(1)
+	for _, i := range []int{-1, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("At(%d) did not panic", i)
+				}
+			}()
+			d.At(i)
+		}()
+	}
+}
+
+func BenchmarkQueue(b *testing.B) {
+	b.Run("Deque", func(b *testing.B) {
+		b.ReportAllocs()
+		var d Deque[int]
+		for i := 0; i < b.N; i++ {
+			d.PushBack(i)
+			if d.Len() > 1024 {
+				d.PopFront()
+			}
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		b.ReportAllocs()
+		var l listx.List[int]
+		for i := 0; i < b.N; i++ {
+			l.PushBack(i)
+			if l.Len() > 1024 {
+				l.Remove(l.Front())
+			}
+		}
+	})
+}
+
+func BenchmarkStack(b *testing.B) {
+	b.Run("Deque", func(b *testing.B) {
+		b.ReportAllocs()
+		var d Deque[int]
+		for i := 0; i < b.N; i++ {
+			if i%3 == 2 {
+				d.PopBack()
+			} else {
+				d.PushBack(i)
+			}
+		}
+	})
+	b.Run("List", func(b *testing.B) {
+		b.ReportAllocs()
+		var l listx.List[int]
+		for i := 0; i < b.N; i++ {
+			if i%3 == 2 {
+				l.Remove(l.Back())
+			} else {
+				l.PushBack(i)
+			}
+		}
+	})
+}
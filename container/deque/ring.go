@@ -0,0 +1,127 @@
+package deque
+
+import "iter"
+
+// Ring is a fixed-capacity circular buffer. Push refuses new values once
+// the ring is full, while Overwrite makes room by dropping the oldest one,
+// which keeps a sliding window of the most recent values.
+type Ring[T any] struct {
+	// buf holds the values from oldest to newest starting at buf[head],
+	// wrapping around at the end.
+	buf  []T
+	head int
+	len  int
+}
+
+// NewRing returns an empty ring holding at most capacity values.
+// It panics if capacity is not positive.
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity <= 0 {
+		panic("deque: ring capacity must be positive")
+	}
+	return &Ring[T]{buf: make([]T, capacity)}
+}
+
+// Len returns the number of values in the ring.
+func (r *Ring[T]) Len() int { return r.len }
+
+// Cap returns the capacity of the ring.
+func (r *Ring[T]) Cap() int { return len(r.buf) }
+
+// Full reports whether the ring holds Cap values.
+func (r *Ring[T]) Full() bool { return r.len == len(r.buf) }
+
+// index returns the buffer position of the i-th oldest value.
+func (r *Ring[T]) index(i int) int {
+	if i += r.head; i >= len(r.buf) {
+		i -= len(r.buf)
+	}
+	return i
+}
+
+// Push adds v as the newest value and reports whether it did, it returns
+// false if the ring is full.
+func (r *Ring[T]) Push(v T) bool {
+	if r.Full() {
+		return false
+	}
+	r.buf[r.index(r.len)] = v
+	r.len++
+	return true
+}
+
+// Overwrite adds v as the newest value. If the ring is full the oldest value
+// is dropped to make room and returned, with evicted set.
+func (r *Ring[T]) Overwrite(v T) (old T, evicted bool) {
+	if !r.Full() {
+		r.Push(v)
+		return old, false
+	}
+	old = r.buf[r.head]
+	r.buf[r.head] = v
+	r.head = r.index(1)
+	return old, true
+}
+
+// Pop removes and returns the oldest value, the bool is false if the ring
+// is empty.
+func (r *Ring[T]) Pop() (T, bool) {
+	var zero T
+	if r.len == 0 {
+		return zero, false
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = zero // avoid memory leaks
+	r.head = r.index(1)
+	r.len--
+	return v, true
+}
+
+// Oldest returns the oldest value, the bool is false if the ring is empty.
+func (r *Ring[T]) Oldest() (T, bool) {
+	if r.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.head], true
+}
+
+// Newest returns the newest value, the bool is false if the ring is empty.
+func (r *Ring[T]) Newest() (T, bool) {
+	if r.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.buf[r.index(r.len-1)], true
+}
+
+// At returns the i-th oldest value, At(0) is the oldest.
+// It panics if i is out of range.
+func (r *Ring[T]) At(i int) T {
+	checkIndex(i, r.len)
+	return r.buf[r.index(i)]
+}
+
+// Clear removes all values.
+func (r *Ring[T]) Clear() {
+	clear(r.buf)
+	r.head = 0
+	r.len = 0
+}
+
+// All returns an iterator over the positions and values from oldest to newest.
+func (r *Ring[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < r.len && yield(i, r.buf[r.index(i)]); i++ {
+		}
+	}
+}
+
+// Backward returns an iterator over the positions and values from newest
+// to oldest.
+func (r *Ring[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.len - 1; i >= 0 && yield(i, r.buf[r.index(i)]); i-- {
+		}
+	}
+}
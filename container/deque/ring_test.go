@@ -0,0 +1,77 @@
+package deque
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRing(t *testing.T) {
+	r := NewRing[int](3)
+	for i := 0; i < 3; i++ {
+		if !r.Push(i) {
+			t.Fatalf("Push(%d) on a ring with room failed", i)
+		}
+	}
+	if r.Push(3) || !r.Full() {
+		t.Fatalf("Push() on a full ring succeeded")
+	}
+
+	for i := 3; i < 7; i++ {
+		if old, ok := r.Overwrite(i); !ok || old != i-3 {
+			t.Fatalf("Overwrite(%d) = %d, %v; want %d, true", i, old, ok, i-3)
+		}
+	}
+	if got := values(r.All()); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("All() = %v; want [4 5 6]", got)
+	}
+	if got := values(r.Backward()); !slices.Equal(got, []int{6, 5, 4}) {
+		t.Errorf("Backward() = %v; want [6 5 4]", got)
+	}
+	if r.At(1) != 5 {
+		t.Errorf("At(1) = %d; want 5", r.At(1))
+	}
+	if v, _ := r.Oldest(); v != 4 {
+		t.Errorf("Oldest() = %d; want 4", v)
+	}
+	if v, _ := r.Newest(); v != 6 {
+		t.Errorf("Newest() = %d; want 6", v)
+	}
+
+	if v, ok := r.Pop(); !ok || v != 4 {
+		t.Errorf("Pop() = %d, %v; want 4, true", v, ok)
+	}
+	if _, ok := r.Overwrite(7); ok || r.Len() != 3 {
+		t.Errorf("Overwrite() on a ring with room evicted a value")
+	}
+	if got := values(r.All()); !slices.Equal(got, []int{5, 6, 7}) {
+		t.Errorf("All() = %v; want [5 6 7]", got)
+	}
+
+	r.Clear()
+	if _, ok := r.Pop(); ok || r.Len() != 0 || r.Cap() != 3 {
+		t.Errorf("Clear() did not empty the ring")
+	}
+}
+
+// TestRingWindow keeps a moving sum over the last values, the way a metrics
+// window would.
+func TestRingWindow(t *testing.T) {
+	r := NewRing[int](4)
+	sum := 0
+	for i := 1; i <= 10; i++ {
+		if old, ok := r.Overwrite(i); ok {
+			sum -= old
+		}
+		sum += i
+		want := 0
+		for _, v := range r.All() {
+			want += v
+		}
+		if sum != want {
+			t.Fatalf("window sum after %d = %d; want %d", i, sum, want)
+		}
+	}
+	if sum != 7+8+9+10 {
+		t.Errorf("window sum = %d; want %d", sum, 7+8+9+10)
+	}
+}
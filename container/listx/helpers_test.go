@@ -0,0 +1,229 @@
+package listx
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// checkList verifies the links of l in both directions against want.
+func checkList[V comparable](t *testing.T, name string, l *List[V], want []V) {
+	t.Helper()
+	if got := l.ToSlice(); !slices.Equal(got, want) {
+		t.Fatalf("%s = %v; want %v", name, got, want)
+	}
+	got := slices.Collect(l.Backward())
+	slices.Reverse(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("%s: Backward() = %v; want the reverse of %v", name, got, want)
+	}
+	if l.Len() != len(want) {
+		t.Fatalf("%s: Len() = %d; want %d", name, l.Len(), len(want))
+	}
+	for e := l.Front(); e != nil; e = e.Next() {
+		if e.list() != l {
+			t.Fatalf("%s: element %v does not belong to the list", name, e.Value)
+		}
+	}
+}
+
+func TestFindAndRemoveIf(t *testing.T) {
+	l := FromSlice([]int{1, 2, 3, 4, 5, 6})
+	if e := l.Find(func(v int) bool { return v > 3 }); e == nil || e.Value != 4 {
+		t.Errorf("Find(> 3) = %v; want 4", e)
+	}
+	if e := l.Find(func(v int) bool { return v > 6 }); e != nil {
+		t.Errorf("Find(> 6) = %v; want nil", e.Value)
+	}
+	if n := l.RemoveIf(func(v int) bool { return v%2 == 0 }); n != 3 {
+		t.Errorf("RemoveIf(even) = %d; want 3", n)
+	}
+	checkList(t, "RemoveIf", l, []int{1, 3, 5})
+
+	for e := range l.Elements() {
+		if e.Value == 3 {
+			break
+		}
+		l.Remove(e)
+	}
+	checkList(t, "Elements", l, []int{3, 5})
+
+	var empty List[int]
+	for range empty.All() {
+		t.Errorf("All() on a zero List yielded a value")
+	}
+}
+
+func TestFilterAndMap(t *testing.T) {
+	l := FromSlice([]int{1, 2, 3, 4})
+	checkList(t, "Filter", l.Filter(func(v int) bool { return v > 2 }), []int{3, 4})
+	checkList(t, "Map", Map(l, strconv.Itoa), []string{"1", "2", "3", "4"})
+	checkList(t, "source", l, []int{1, 2, 3, 4})
+}
+
+func TestReverse(t *testing.T) {
+	for n := 0; n < 5; n++ {
+		want := make([]int, n)
+		for i := range want {
+			want[i] = i
+		}
+		l := FromSlice(want)
+		front := l.Front()
+		l.Reverse()
+		slices.Reverse(want)
+		checkList(t, "Reverse", l, want)
+		if n > 0 && l.Back() != front {
+			t.Errorf("Reverse() did not keep the elements")
+		}
+	}
+}
+
+func TestSort(t *testing.T) {
+	type pair struct{ key, seq int }
+	for n := 0; n < 200; n += 7 {
+		want := make([]pair, n)
+		for i := range want {
+			want[i] = pair{rand.Intn(10), i}
+		}
+		l := FromSlice(want)
+		elems := make(map[pair]*Element[pair])
+		for e := l.Front(); e != nil; e = e.Next() {
+			elems[e.Value] = e
+		}
+
+		byKey := func(a, b pair) int { return cmp.Compare(a.key, b.key) }
+		l.Sort(byKey)
+		slices.SortStableFunc(want, byKey)
+		checkList(t, "Sort", l, want)
+		for e := l.Front(); e != nil; e = e.Next() {
+			if elems[e.Value] != e {
+				t.Fatalf("Sort() replaced the element holding %v", e.Value)
+			}
+		}
+	}
+}
+
+func TestSplice(t *testing.T) {
+	l := FromSlice([]int{1, 5})
+	mid := FromSlice([]int{2, 3, 4})
+	moved := mid.Front()
+	l.SpliceBefore(l.Back(), mid)
+	checkList(t, "SpliceBefore", l, []int{1, 2, 3, 4, 5})
+	checkList(t, "spliced list", mid, nil)
+	if moved.list() != l || moved.Prev().Value != 1 {
+		t.Errorf("SpliceBefore() did not move the elements")
+	}
+
+	l.SpliceAfter(l.Back(), FromSlice([]int{6}))
+	l.SpliceFront(FromSlice([]int{-1, 0}))
+	l.SpliceBack(FromSlice([]int{7}))
+	checkList(t, "Splice", l, []int{-1, 0, 1, 2, 3, 4, 5, 6, 7})
+
+	l.SpliceBack(l)
+	l.SpliceBefore(new(Element[int]), FromSlice([]int{8}))
+	checkList(t, "no-op Splice", l, []int{-1, 0, 1, 2, 3, 4, 5, 6, 7})
+
+	var zero List[int]
+	zero.SpliceBack(FromSlice([]int{1}))
+	checkList(t, "Splice into a zero List", &zero, []int{1})
+}
+
+func TestSpliceChains(t *testing.T) {
+	// splice lists into each other in both directions so the handle sets
+	// of the elements are nested several levels deep
+	lists := make([]*List[int], 8)
+	elems := make(map[int]*Element[int])
+	for i := range lists {
+		lists[i] = New[int]()
+		for j := 0; j < 3; j++ {
+			elems[i*3+j] = lists[i].PushBack(i*3 + j)
+		}
+	}
+	for step := 1; step < len(lists); step *= 2 {
+		for i := 0; i+step < len(lists); i += 2 * step {
+			if i%4 == 0 {
+				lists[i].SpliceBack(lists[i+step])
+			} else {
+				lists[i+step].SpliceFront(lists[i])
+				lists[i], lists[i+step] = lists[i+step], lists[i]
+			}
+		}
+	}
+	want := make([]int, len(elems))
+	for i := range want {
+		want[i] = i
+	}
+	checkList(t, "spliced chain", lists[0], want)
+	for v, e := range elems {
+		if e.list() != lists[0] || e.Value != v {
+			t.Fatalf("element %d was not moved to the final list", v)
+		}
+	}
+
+	lists[0].Init()
+	for v, e := range elems {
+		if e.list() != nil {
+			t.Fatalf("element %d still belongs to a cleared list", v)
+		}
+	}
+	lists[0].Remove(elems[0])
+	checkList(t, "cleared list", lists[0], nil)
+}
+
+func TestTransferTo(t *testing.T) {
+	a, b := FromSlice([]int{1, 2, 3}), New[int]()
+	store := make(map[int]*Element[int])
+	for e := a.Front(); e != nil; e = e.Next() {
+		store[e.Value] = e
+	}
+
+	a.TransferTo(store[2], b, nil)
+	a.TransferTo(store[1], b, b.Front())
+	a.TransferTo(store[3], b, nil)
+	checkList(t, "source", a, nil)
+	checkList(t, "target", b, []int{1, 2, 3})
+	for v, e := range store {
+		if e.list() != b || e.Value != v {
+			t.Errorf("element %d was not moved to the target list", v)
+		}
+	}
+
+	b.TransferTo(store[3], b, store[1])
+	checkList(t, "TransferTo within a list", b, []int{3, 1, 2})
+
+	// not an element of the receiver, or a mark of another list
+	a.TransferTo(store[1], b, nil)
+	b.TransferTo(store[1], a, store[2])
+	checkList(t, "no-op TransferTo", b, []int{3, 1, 2})
+
+	var zero List[int]
+	b.TransferTo(store[1], &zero, nil)
+	checkList(t, "TransferTo a zero List", &zero, []int{1})
+}
+
+func TestMoveRange(t *testing.T) {
+	l := FromSlice([]int{0, 1, 2, 3, 4, 5})
+	elems := slices.Collect(l.Elements())
+	l.MoveRangeBefore(elems[3], elems[5], elems[1])
+	checkList(t, "MoveRangeBefore", l, []int{0, 3, 4, 5, 1, 2})
+	l.MoveRangeAfter(elems[3], elems[4], elems[2])
+	checkList(t, "MoveRangeAfter", l, []int{0, 5, 1, 2, 3, 4})
+	l.MoveRangeBefore(elems[0], elems[4], elems[5])
+	checkList(t, "MoveRangeBefore the whole list", l, []int{0, 5, 1, 2, 3, 4})
+	l.MoveRangeAfter(elems[0], elems[5], elems[4])
+	checkList(t, "MoveRangeAfter to the back", l, []int{1, 2, 3, 4, 0, 5})
+	for i, e := range elems {
+		if e.Value != i || e.list() != l {
+			t.Fatalf("MoveRange replaced the element holding %d", i)
+		}
+	}
+
+	// reversed bounds, a mark inside the run and an element of another list
+	other := FromSlice([]int{9})
+	l.MoveRangeBefore(elems[3], elems[1], elems[0])
+	l.MoveRangeAfter(elems[1], elems[4], elems[3])
+	l.MoveRangeAfter(elems[1], elems[2], other.Front())
+	checkList(t, "no-op MoveRange", l, []int{1, 2, 3, 4, 0, 5})
+}
@@ -0,0 +1,269 @@
+package listx
+
+import "iter"
+
+// Link is the header a struct embeds to be kept in an IntrusiveList, the
+// list then links the structs themselves and allocates nothing:
+//
+//	type job struct {
+//		listx.Link[*job]
+//		id int
+//	}
+//
+//	var queue listx.IntrusiveList[*job]
+//	queue.PushBack(&job{id: 1})
+//
+// A value is in at most one list at a time. The zero Link is not in any list.
+type Link[T any] struct {
+	// Same ring as List: &l.root is both the next link of the last value
+	// and the previous link of the first value.
+	next, prev *Link[T]
+
+	// The sentinel of the list to which this link belongs.
+	list *Link[T]
+
+	// The value embedding this link, set on insert.
+	value T
+}
+
+// Links returns l itself, it lets the embedding type satisfy Linker.
+func (l *Link[T]) Links() *Link[T] { return l }
+
+// Next returns the next value in the list or the zero value.
+func (l *Link[T]) Next() T {
+	if p := l.next; l.list != nil && p != l.list {
+		return p.value
+	}
+	var zero T
+	return zero
+}
+
+// Prev returns the previous value in the list or the zero value.
+func (l *Link[T]) Prev() T {
+	if p := l.prev; l.list != nil && p != l.list {
+		return p.value
+	}
+	var zero T
+	return zero
+}
+
+// unlink resets l to the zero Link without touching its neighbours.
+func (l *Link[T]) unlink() {
+	l.next = nil // avoid memory leaks
+	l.prev = nil // avoid memory leaks
+	l.list = nil
+	var zero T
+	l.value = zero
+}
+
+// Linked reports whether the value is in a list.
+func (l *Link[T]) Linked() bool { return l.list != nil }
+
+// Linker is implemented by pointers to structs that embed a Link.
+type Linker[T any] interface {
+	Links() *Link[T]
+}
+
+// IntrusiveList is a doubly linked list of values that embed their own
+// Link, T is usually a pointer to the embedding struct.
+// The zero value for IntrusiveList is an empty list ready to use.
+type IntrusiveList[T Linker[T]] struct {
+	root Link[T] // sentinel link, only &root, root.prev, and root.next are used
+	len  int     // current list length excluding (this) sentinel link
+}
+
+// Init initializes or clears list l. Clearing unlinks every value, so the
+// values can be inserted again, which takes O(n).
+func (l *IntrusiveList[T]) Init() *IntrusiveList[T] {
+	for e := l.root.next; e != nil && e != &l.root; {
+		next := e.next
+		e.unlink()
+		e = next
+	}
+	l.root.next = &l.root
+	l.root.prev = &l.root
+	l.len = 0
+	return l
+}
+
+// NewIntrusive returns an initialized intrusive list.
+func NewIntrusive[T Linker[T]]() *IntrusiveList[T] { return new(IntrusiveList[T]).Init() }
+
+// Len returns the number of values of list l.
+// The complexity is O(1).
+func (l *IntrusiveList[T]) Len() int { return l.len }
+
+// Front returns the first value of list l or the zero value if the list is empty.
+func (l *IntrusiveList[T]) Front() T {
+	if l.len == 0 {
+		var zero T
+		return zero
+	}
+	return l.root.next.value
+}
+
+// Back returns the last value of list l or the zero value if the list is empty.
+func (l *IntrusiveList[T]) Back() T {
+	if l.len == 0 {
+		var zero T
+		return zero
+	}
+	return l.root.prev.value
+}
+
+// lazyInit lazily initializes a zero IntrusiveList value.
+func (l *IntrusiveList[T]) lazyInit() {
+	if l.root.next == nil {
+		l.Init()
+	}
+}
+
+// insert links v after at, increments l.len and reports whether v was
+// inserted. If v is already in a list nothing changes.
+func (l *IntrusiveList[T]) insert(v T, at *Link[T]) bool {
+	e := v.Links()
+	if e.list != nil {
+		return false
+	}
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+	e.list = &l.root
+	e.value = v
+	l.len++
+	return true
+}
+
+// remove unlinks e from its list, decrements l.len
+func (l *IntrusiveList[T]) remove(e *Link[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.unlink()
+	l.len--
+}
+
+// move moves e to next to at.
+func (l *IntrusiveList[T]) move(e, at *Link[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+
+	e.prev = at
+	e.next = at.next
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// Remove removes v from l if v is in list l.
+func (l *IntrusiveList[T]) Remove(v T) {
+	if e := v.Links(); e.list == &l.root {
+		l.remove(e)
+	}
+}
+
+// PushFront inserts v at the front of list l and reports whether it did.
+// If v is already in a list, no list is modified and false is returned.
+func (l *IntrusiveList[T]) PushFront(v T) bool {
+	l.lazyInit()
+	return l.insert(v, &l.root)
+}
+
+// PushBack inserts v at the back of list l and reports whether it did.
+// If v is already in a list, no list is modified and false is returned.
+func (l *IntrusiveList[T]) PushBack(v T) bool {
+	l.lazyInit()
+	return l.insert(v, l.root.prev)
+}
+
+// InsertBefore inserts v immediately before mark and reports whether it did.
+// If mark is not in l, or v is already in a list, the list is not modified
+// and false is returned.
+func (l *IntrusiveList[T]) InsertBefore(v, mark T) bool {
+	if m := mark.Links(); m.list == &l.root {
+		return l.insert(v, m.prev)
+	}
+	return false
+}
+
+// InsertAfter inserts v immediately after mark and reports whether it did.
+// If mark is not in l, or v is already in a list, the list is not modified
+// and false is returned.
+func (l *IntrusiveList[T]) InsertAfter(v, mark T) bool {
+	if m := mark.Links(); m.list == &l.root {
+		return l.insert(v, m)
+	}
+	return false
+}
+
+// MoveToFront moves v to the front of list l.
+// If v is not in l, the list is not modified.
+func (l *IntrusiveList[T]) MoveToFront(v T) {
+	if e := v.Links(); e.list == &l.root && l.root.next != e {
+		l.move(e, &l.root)
+	}
+}
+
+// MoveToBack moves v to the back of list l.
+// If v is not in l, the list is not modified.
+func (l *IntrusiveList[T]) MoveToBack(v T) {
+	if e := v.Links(); e.list == &l.root && l.root.prev != e {
+		l.move(e, l.root.prev)
+	}
+}
+
+// MoveBefore moves v to its new position before mark.
+// If v or mark is not in l, or v == mark, the list is not modified.
+func (l *IntrusiveList[T]) MoveBefore(v, mark T) {
+	e, m := v.Links(), mark.Links()
+	if e.list != &l.root || e == m || m.list != &l.root {
+		return
+	}
+	l.move(e, m.prev)
+}
+
+// MoveAfter moves v to its new position after mark.
+// If v or mark is not in l, or v == mark, the list is not modified.
+func (l *IntrusiveList[T]) MoveAfter(v, mark T) {
+	e, m := v.Links(), mark.Links()
+	if e.list != &l.root || e == m || m.list != &l.root {
+		return
+	}
+	l.move(e, m)
+}
+
+// All returns an iterator over the values of list l from front to back.
+// The loop body may remove the value it was given.
+func (l *IntrusiveList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.len == 0 {
+			return
+		}
+		for e := l.root.next; e != &l.root; {
+			next := e.next
+			if !yield(e.value) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Backward returns an iterator over the values of list l from back to front.
+// The loop body may remove the value it was given.
+func (l *IntrusiveList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if l.len == 0 {
+			return
+		}
+		for e := l.root.prev; e != &l.root; {
+			prev := e.prev
+			if !yield(e.value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package listx
+
+import (
+	"slices"
+	"testing"
+)
+
+type job struct {
+	Link[*job]
+	id int
+}
+
+func jobIDs(seq func(func(*job) bool)) []int {
+	var ids []int
+	for j := range seq {
+		ids = append(ids, j.id)
+	}
+	return ids
+}
+
+func checkIntrusive(t *testing.T, name string, l *IntrusiveList[*job], want []int) {
+	t.Helper()
+	if got := jobIDs(l.All()); !slices.Equal(got, want) {
+		t.Fatalf("%s = %v; want %v", name, got, want)
+	}
+	got := jobIDs(l.Backward())
+	slices.Reverse(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("%s: Backward() = %v; want the reverse of %v", name, got, want)
+	}
+	if l.Len() != len(want) {
+		t.Fatalf("%s: Len() = %d; want %d", name, l.Len(), len(want))
+	}
+}
+
+func TestIntrusiveList(t *testing.T) {
+	var l IntrusiveList[*job]
+	if l.Front() != nil || l.Back() != nil {
+		t.Errorf("a zero IntrusiveList should be empty")
+	}
+	jobs := make([]*job, 6)
+	for i := range jobs {
+		jobs[i] = &job{id: i}
+	}
+
+	l.PushBack(jobs[1])
+	l.PushFront(jobs[0])
+	l.PushBack(jobs[3])
+	l.InsertBefore(jobs[2], jobs[3])
+	l.InsertAfter(jobs[4], jobs[3])
+	checkIntrusive(t, "insert", &l, []int{0, 1, 2, 3, 4})
+	if l.Front() != jobs[0] || l.Back() != jobs[4] {
+		t.Errorf("Front(), Back() = %d, %d; want 0, 4", l.Front().id, l.Back().id)
+	}
+	if jobs[2].Next() != jobs[3] || jobs[2].Prev() != jobs[1] || jobs[4].Next() != nil || jobs[0].Prev() != nil {
+		t.Errorf("Next() or Prev() does not follow the list order")
+	}
+
+	// a linked value is never inserted twice
+	other := NewIntrusive[*job]()
+	if l.PushBack(jobs[0]) || other.PushFront(jobs[0]) || other.PushBack(jobs[1]) {
+		t.Errorf("pushing a linked value reported success")
+	}
+	if l.InsertAfter(jobs[5], &job{id: 9}) || l.InsertBefore(jobs[1], jobs[2]) {
+		t.Errorf("InsertAfter or InsertBefore reported success without inserting")
+	}
+	checkIntrusive(t, "duplicate insert", &l, []int{0, 1, 2, 3, 4})
+	checkIntrusive(t, "other", other, nil)
+
+	l.MoveToFront(jobs[4])
+	l.MoveToBack(jobs[0])
+	l.MoveBefore(jobs[3], jobs[1])
+	l.MoveAfter(jobs[2], jobs[4])
+	checkIntrusive(t, "move", &l, []int{4, 2, 3, 1, 0})
+
+	l.Remove(jobs[3])
+	other.Remove(jobs[2])
+	checkIntrusive(t, "remove", &l, []int{4, 2, 1, 0})
+	if jobs[3].Linked() || !jobs[2].Linked() {
+		t.Errorf("Linked() does not match membership")
+	}
+
+	for j := range l.All() {
+		if j.id%2 == 0 {
+			l.Remove(j)
+		}
+	}
+	checkIntrusive(t, "remove while iterating", &l, []int{1})
+	other.PushBack(jobs[4])
+	checkIntrusive(t, "reinsert", other, []int{4})
+}
+
+func TestIntrusiveInit(t *testing.T) {
+	l := NewIntrusive[*job]()
+	jobs := []*job{{id: 0}, {id: 1}, {id: 2}}
+	for _, j := range jobs {
+		l.PushBack(j)
+	}
+	l.Init()
+	checkIntrusive(t, "cleared", l, nil)
+	for _, j := range jobs {
+		if j.Linked() || j.Next() != nil || j.Prev() != nil {
+			t.Fatalf("job %d is still linked after Init", j.id)
+		}
+	}
+
+	// a stale value is neither removed from nor skipped by the cleared list
+	l.Remove(jobs[1])
+	checkIntrusive(t, "Remove after Init", l, nil)
+	if !l.PushBack(jobs[1]) || !l.PushFront(jobs[2]) || !l.InsertAfter(jobs[0], jobs[2]) {
+		t.Fatalf("values cleared by Init could not be inserted again")
+	}
+	checkIntrusive(t, "reinsert after Init", l, []int{2, 0, 1})
+}
+
+func BenchmarkPushPop(b *testing.B) {
+	b.Run("List", func(b *testing.B) {
+		b.ReportAllocs()
+		l := New[job]()
+		for i := 0; i < b.N; i++ {
+			l.PushBack(job{id: i})
+			if l.Len() > 1024 {
+				l.Remove(l.Front())
+			}
+		}
+	})
+	b.Run("IntrusiveList", func(b *testing.B) {
+		b.ReportAllocs()
+		// the jobs are owned by the caller and recycled, as a scheduler would
+		jobs := make([]job, 1025)
+		l := NewIntrusive[*job]()
+		for i := 0; i < b.N; i++ {
+			j := &jobs[i%len(jobs)]
+			j.id = i
+			l.PushBack(j)
+			if l.Len() > 1024 {
+				l.Remove(l.Front())
+			}
+		}
+	})
+}
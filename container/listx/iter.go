@@ -0,0 +1,56 @@
+package listx
+
+import "iter"
+
+// All returns an iterator over the values of list l from front to back.
+func (l *List[V]) All() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for e := l.Front(); e != nil && yield(e.Value); e = e.Next() {
+		}
+	}
+}
+
+// Backward returns an iterator over the values of list l from back to front.
+func (l *List[V]) Backward() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for e := l.Back(); e != nil && yield(e.Value); e = e.Prev() {
+		}
+	}
+}
+
+// Elements returns an iterator over the elements of list l from front to back.
+// The loop body may remove the element it was given.
+func (l *List[V]) Elements() iter.Seq[*Element[V]] {
+	return func(yield func(*Element[V]) bool) {
+		for e := l.Front(); e != nil; {
+			next := e.Next()
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Find returns the first element of list l whose value satisfies pred, or nil.
+func (l *List[V]) Find(pred func(V) bool) *Element[V] {
+	for e := l.Front(); e != nil; e = e.Next() {
+		if pred(e.Value) {
+			return e
+		}
+	}
+	return nil
+}
+
+// RemoveIf removes every element of list l whose value satisfies pred
+// and returns how many were removed.
+func (l *List[V]) RemoveIf(pred func(V) bool) int {
+	cnt := 0
+	for e := range l.Elements() {
+		if pred(e.Value) {
+			l.remove(e)
+			cnt++
+		}
+	}
+	return cnt
+}
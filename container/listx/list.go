@@ -10,8 +10,8 @@ type Element[V any] struct {
 	// element (l.Front()).
 	next, prev *Element[V]
 
-	// The list to which this element belongs.
-	list *List[V]
+	// The handle of the list to which this element belongs, see handle.
+	handle *handle[V]
 
 	// The value stored with this element.
 	Value V
@@ -19,7 +19,8 @@ type Element[V any] struct {
 
 // Next returns the next list element or nil.
 func (e *Element[V]) Next() *Element[V] {
-	if p := e.next; e.list != nil && p != &e.list.root {
+	// the sentinel is the only linked element without a handle
+	if p := e.next; p != nil && p.handle != nil {
 		return p
 	}
 	return nil
@@ -27,17 +28,48 @@ func (e *Element[V]) Next() *Element[V] {
 
 // Prev returns the previous list element or nil.
 func (e *Element[V]) Prev() *Element[V] {
-	if p := e.prev; e.list != nil && p != &e.list.root {
+	if p := e.prev; p != nil && p.handle != nil {
 		return p
 	}
 	return nil
 }
 
+// list returns the list e belongs to, or nil.
+func (e *Element[V]) list() *List[V] {
+	if e.handle == nil {
+		return nil
+	}
+	return e.handle.find().list
+}
+
+// handle tells elements which list they belong to. Handles form a disjoint
+// set forest whose roots point at a list, so splicing a whole list moves
+// all of its elements by linking two roots, in O(1), instead of relabeling
+// every element. Lookups compress the paths they walk.
+type handle[V any] struct {
+	parent *handle[V]
+	list   *List[V]
+	rank   int
+}
+
+// find returns the root of the set h belongs to.
+func (h *handle[V]) find() *handle[V] {
+	root := h
+	for root.parent != nil {
+		root = root.parent
+	}
+	for h != root {
+		h, h.parent = h.parent, root
+	}
+	return root
+}
+
 // List represents a doubly linked list.
 // The zero value for List is an empty list ready to use.
 type List[V any] struct {
-	root Element[V] // sentinel list element, only &root, root.prev, and root.next are used
-	len  int        // current list length excluding (this) sentinel element
+	root   Element[V] // sentinel list element, only &root, root.prev, and root.next are used
+	len    int        // current list length excluding (this) sentinel element
+	handle *handle[V] // root handle shared by the elements, set by Init
 }
 
 // Init initializes or clears list l.
@@ -45,6 +77,11 @@ func (l *List[V]) Init() *List[V] {
 	l.root.next = &l.root
 	l.root.prev = &l.root
 	l.len = 0
+	if l.handle != nil {
+		// elements left behind by a clear no longer belong to l
+		l.handle.list = nil
+	}
+	l.handle = &handle[V]{list: l}
 	return l
 }
 
@@ -84,7 +121,7 @@ func (l *List[V]) insert(e, at *Element[V]) *Element[V] {
 	e.next = at.next
 	e.prev.next = e
 	e.next.prev = e
-	e.list = l
+	e.handle = l.handle
 	l.len++
 	return e
 }
@@ -100,7 +137,7 @@ func (l *List[V]) remove(e *Element[V]) {
 	e.next.prev = e.prev
 	e.next = nil // avoid memory leaks
 	e.prev = nil // avoid memory leaks
-	e.list = nil
+	e.handle = nil
 	l.len--
 }
 
@@ -122,8 +159,8 @@ func (l *List[V]) move(e, at *Element[V]) {
 // It returns the element value e.Value.
 // The element must not be nil.
 func (l *List[V]) Remove(e *Element[V]) V {
-	if e.list == l {
-		// if e.list == l, l must have been initialized when e was inserted
+	if e.list() == l {
+		// if e.list() == l, l must have been initialized when e was inserted
 		// in l or l == nil (e is a zero Element) and l.remove will crash
 		l.remove(e)
 	}
@@ -146,7 +183,7 @@ func (l *List[V]) PushBack(v V) *Element[V] {
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
 func (l *List[V]) InsertBefore(v V, mark *Element[V]) *Element[V] {
-	if mark.list != l {
+	if mark.list() != l {
 		return nil
 	}
 	// see comment in List.Remove about initialization of l
@@ -157,7 +194,7 @@ func (l *List[V]) InsertBefore(v V, mark *Element[V]) *Element[V] {
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
 func (l *List[V]) InsertAfter(v V, mark *Element[V]) *Element[V] {
-	if mark.list != l {
+	if mark.list() != l {
 		return nil
 	}
 	// see comment in List.Remove about initialization of l
@@ -168,7 +205,7 @@ func (l *List[V]) InsertAfter(v V, mark *Element[V]) *Element[V] {
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
 func (l *List[V]) MoveToFront(e *Element[V]) {
-	if e.list != l || l.root.next == e {
+	if e.list() != l || l.root.next == e {
 		return
 	}
 	// see comment in List.Remove about initialization of l
@@ -179,7 +216,7 @@ func (l *List[V]) MoveToFront(e *Element[V]) {
 // If e is not an element of l, the list is not modified.
 // The element must not be nil.
 func (l *List[V]) MoveToBack(e *Element[V]) {
-	if e.list != l || l.root.prev == e {
+	if e.list() != l || l.root.prev == e {
 		return
 	}
 	// see comment in List.Remove about initialization of l
@@ -187,10 +224,11 @@ func (l *List[V]) MoveToBack(e *Element[V]) {
 }
 
 // MoveBefore moves element e to its new position before mark.
-// If e or mark is not an element of l, or e == mark, the list is not modified.
+// If e or mark is not an element of l, or e == mark, the list is not modified,
+// see TransferTo for moving elements between lists.
 // The element and mark must not be nil.
 func (l *List[V]) MoveBefore(e, mark *Element[V]) {
-	if e.list != l || e == mark || mark.list != l {
+	if e.list() != l || e == mark || mark.list() != l {
 		return
 	}
 	l.move(e, mark.prev)
@@ -200,7 +238,7 @@ func (l *List[V]) MoveBefore(e, mark *Element[V]) {
 // If e or mark is not an element of l, or e == mark, the list is not modified.
 // The element and mark must not be nil.
 func (l *List[V]) MoveAfter(e, mark *Element[V]) {
-	if e.list != l || e == mark || mark.list != l {
+	if e.list() != l || e == mark || mark.list() != l {
 		return
 	}
 	l.move(e, mark)
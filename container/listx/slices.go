@@ -0,0 +1,40 @@
+package listx
+
+// FromSlice returns a new list holding the values of s in order.
+func FromSlice[V any](s []V) *List[V] {
+	l := New[V]()
+	for _, v := range s {
+		l.insertValue(v, l.root.prev)
+	}
+	return l
+}
+
+// ToSlice returns the values of list l from front to back.
+func (l *List[V]) ToSlice() []V {
+	res := make([]V, 0, l.len)
+	for v := range l.All() {
+		res = append(res, v)
+	}
+	return res
+}
+
+// Filter returns a new list holding, in order, the values of list l that
+// satisfy pred. List l is not modified.
+func (l *List[V]) Filter(pred func(V) bool) *List[V] {
+	res := New[V]()
+	for v := range l.All() {
+		if pred(v) {
+			res.insertValue(v, res.root.prev)
+		}
+	}
+	return res
+}
+
+// Map returns a new list holding f applied to every value of list l, in order.
+func Map[V, W any](l *List[V], f func(V) W) *List[W] {
+	res := New[W]()
+	for v := range l.All() {
+		res.insertValue(f(v), res.root.prev)
+	}
+	return res
+}
@@ -0,0 +1,73 @@
+package listx
+
+// Reverse reverses the order of the elements of list l in place.
+// The elements themselves are kept, only their links change.
+func (l *List[V]) Reverse() {
+	if l.len < 2 {
+		return
+	}
+	// swapping next and prev of every element, the sentinel included,
+	// reverses the ring
+	e := &l.root
+	for {
+		e.next, e.prev = e.prev, e.next
+		if e = e.prev; e == &l.root {
+			return
+		}
+	}
+}
+
+// Sort sorts the elements of list l by cmp, which must return a negative
+// number when a < b, zero when a == b and a positive number when a > b.
+// The sort is stable and relinks the elements in place, so pointers to
+// them stay valid. The complexity is O(n log n).
+func (l *List[V]) Sort(cmp func(a, b V) int) {
+	if l.len < 2 {
+		return
+	}
+	head, _ := mergeSort(l.root.next, l.len, cmp)
+
+	// the sort only maintains next links, restore prev and close the ring
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		prev.next = e
+		e.prev = prev
+		prev = e
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// mergeSort sorts the n elements linked by next from head on. It returns
+// the head of the sorted run, which ends with a nil next link, and the
+// element that followed the n elements.
+func mergeSort[V any](head *Element[V], n int, cmp func(a, b V) int) (sorted, rest *Element[V]) {
+	if n == 1 {
+		rest = head.next
+		head.next = nil
+		return head, rest
+	}
+	a, rest := mergeSort(head, n/2, cmp)
+	b, rest := mergeSort(rest, n-n/2, cmp)
+	return merge(a, b, cmp), rest
+}
+
+// merge merges the sorted runs a and b, taking from a on ties.
+func merge[V any](a, b *Element[V], cmp func(a, b V) int) *Element[V] {
+	var head Element[V]
+	tail := &head
+	for a != nil && b != nil {
+		if cmp(a.Value, b.Value) <= 0 {
+			tail.next, a = a, a.next
+		} else {
+			tail.next, b = b, b.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	return head.next
+}
@@ -0,0 +1,148 @@
+package listx
+
+// adopt makes the elements of other belong to l by linking the handle
+// sets of both lists, the root with the lower rank goes below the other.
+func (l *List[V]) adopt(other *List[V]) {
+	a, b := l.handle, other.handle
+	if a.rank < b.rank {
+		a, b = b, a
+	}
+	b.parent = a
+	if a.rank == b.rank {
+		a.rank++
+	}
+	a.list = l
+	l.handle = a
+	// other starts over with a handle of its own
+	other.handle = nil
+}
+
+// splice moves every element of other right after at, which must be
+// an element or the sentinel of list l, and leaves other empty.
+func (l *List[V]) splice(other *List[V], at *Element[V]) {
+	if other == l || other.len == 0 {
+		return
+	}
+	first, last := other.root.next, other.root.prev
+	l.adopt(other)
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+	l.len += other.len
+	other.Init()
+}
+
+// SpliceBefore moves all elements of other immediately before mark and
+// leaves other empty. The elements keep their identity, so pointers to them
+// stay valid. If mark is not an element of l, or other is l, neither list
+// is modified. The mark must not be nil.
+// The complexity is O(1).
+func (l *List[V]) SpliceBefore(mark *Element[V], other *List[V]) {
+	if mark.list() != l {
+		return
+	}
+	l.splice(other, mark.prev)
+}
+
+// SpliceAfter moves all elements of other immediately after mark and
+// leaves other empty, see SpliceBefore.
+func (l *List[V]) SpliceAfter(mark *Element[V], other *List[V]) {
+	if mark.list() != l {
+		return
+	}
+	l.splice(other, mark)
+}
+
+// SpliceFront moves all elements of other to the front of list l and
+// leaves other empty. The lists must not be nil.
+func (l *List[V]) SpliceFront(other *List[V]) {
+	l.lazyInit()
+	l.splice(other, &l.root)
+}
+
+// SpliceBack moves all elements of other to the back of list l and
+// leaves other empty. The lists must not be nil.
+func (l *List[V]) SpliceBack(other *List[V]) {
+	l.lazyInit()
+	l.splice(other, l.root.prev)
+}
+
+// TransferTo moves element e from list l into list other, immediately before
+// mark, or to the back of other if mark is nil. The element keeps its
+// identity, so pointers to it stay valid. If e is not an element of l, or
+// mark is not an element of other, neither list is modified. The element
+// must not be nil. The complexity is O(1).
+func (l *List[V]) TransferTo(e *Element[V], other *List[V], mark *Element[V]) {
+	if e.list() != l || e == mark {
+		return
+	}
+	if other == l {
+		if mark == nil {
+			l.MoveToBack(e)
+		} else {
+			l.MoveBefore(e, mark)
+		}
+		return
+	}
+	other.lazyInit()
+	at := other.root.prev
+	if mark != nil {
+		if mark.list() != other {
+			return
+		}
+		at = mark.prev
+	}
+	l.remove(e)
+	other.insert(e, at)
+}
+
+// moveRange moves the run of elements from first to last right after at,
+// which must be an element or the sentinel of l outside the run.
+func (l *List[V]) moveRange(first, last, at *Element[V]) {
+	first.prev.next = last.next
+	last.next.prev = first.prev
+
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+}
+
+// inRange reports whether first to last is a run of elements of l, with
+// last at or after first, that does not contain mark.
+func (l *List[V]) inRange(first, last, mark *Element[V]) bool {
+	if first.list() != l || last.list() != l || mark.list() != l {
+		return false
+	}
+	for e := first; e != nil; e = e.Next() {
+		if e == mark {
+			return false
+		}
+		if e == last {
+			return true
+		}
+	}
+	return false
+}
+
+// MoveRangeBefore moves the elements from first to last, both included,
+// immediately before mark within list l. The elements keep their identity.
+// If first, last or mark is not an element of l, last comes before first,
+// or mark lies between them, the list is not modified. The elements must
+// not be nil. The complexity is O(k) for a run of k elements.
+func (l *List[V]) MoveRangeBefore(first, last, mark *Element[V]) {
+	if !l.inRange(first, last, mark) || last.next == mark {
+		return
+	}
+	l.moveRange(first, last, mark.prev)
+}
+
+// MoveRangeAfter moves the elements from first to last, both included,
+// immediately after mark within list l, see MoveRangeBefore.
+func (l *List[V]) MoveRangeAfter(first, last, mark *Element[V]) {
+	if !l.inRange(first, last, mark) || first.prev == mark {
+		return
+	}
+	l.moveRange(first, last, mark)
+}
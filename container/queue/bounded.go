@@ -0,0 +1,125 @@
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type slot[T any] struct {
+	// seq tells whose turn the slot is: it equals the enqueue position when
+	// the slot is free for that position, and position+1 once it holds the
+	// value enqueued there.
+	seq   atomic.Uint64
+	value T
+}
+
+// Bounded is a fixed-capacity queue on a ring buffer (Vyukov's bounded
+// MPMC queue). Producers and consumers claim positions with a CAS and hand
+// slots over through per-slot sequence numbers, no lock is taken. Enqueue
+// blocks while the queue is full and Dequeue while it is empty.
+// The zero value is not usable, create one with NewBounded.
+type Bounded[T any] struct {
+	slots []slot[T]
+	// head and tail are the next positions to dequeue from and enqueue to,
+	// they only grow and are reduced modulo len(slots) to find the slot
+	head, tail atomic.Uint64
+
+	notEmpty, notFull waiter
+}
+
+// NewBounded returns an empty queue holding at most capacity values.
+// It panics if capacity is not positive.
+func NewBounded[T any](capacity int) *Bounded[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+	q := &Bounded[T]{
+		slots:    make([]slot[T], capacity),
+		notEmpty: newWaiter(),
+		notFull:  newWaiter(),
+	}
+	for i := range q.slots {
+		q.slots[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// Cap returns the capacity of the queue.
+func (q *Bounded[T]) Cap() int {
+	return len(q.slots)
+}
+
+// Len returns the number of values in the queue. It is exact only while no
+// other goroutine uses the queue.
+func (q *Bounded[T]) Len() int {
+	head, tail := q.head.Load(), q.tail.Load()
+	if tail < head {
+		return 0
+	}
+	return min(int(tail-head), len(q.slots))
+}
+
+// TryEnqueue adds v to the back of the queue and reports whether it did,
+// it returns false without blocking if the queue is full.
+func (q *Bounded[T]) TryEnqueue(v T) bool {
+	for {
+		pos := q.tail.Load()
+		s := &q.slots[pos%uint64(len(q.slots))]
+		switch diff := int64(s.seq.Load() - pos); {
+		case diff == 0:
+			if q.tail.CompareAndSwap(pos, pos+1) {
+				s.value = v
+				s.seq.Store(pos + 1)
+				q.notEmpty.wake()
+				return true
+			}
+		case diff < 0:
+			// the value enqueued a lap ago is still there
+			return false
+		}
+		// another producer claimed pos, retry with the next one
+	}
+}
+
+// TryDequeue removes and returns the front value, the bool is false if the
+// queue is empty. It never blocks.
+func (q *Bounded[T]) TryDequeue() (T, bool) {
+	for {
+		pos := q.head.Load()
+		s := &q.slots[pos%uint64(len(q.slots))]
+		switch diff := int64(s.seq.Load() - (pos + 1)); {
+		case diff == 0:
+			if q.head.CompareAndSwap(pos, pos+1) {
+				v := s.value
+				var zero T
+				s.value = zero
+				// free the slot for the producer one lap ahead
+				s.seq.Store(pos + uint64(len(q.slots)))
+				q.notFull.wake()
+				return v, true
+			}
+		case diff < 0:
+			var zero T
+			return zero, false
+		}
+	}
+}
+
+// Enqueue adds v to the back of the queue, waiting for room if the queue is
+// full. It returns ctx.Err() if ctx is done first, v is not added then.
+func (q *Bounded[T]) Enqueue(ctx context.Context, v T) error {
+	return q.notFull.wait(ctx, func() bool {
+		return q.TryEnqueue(v)
+	})
+}
+
+// Dequeue removes and returns the front value, waiting for one to be
+// enqueued if the queue is empty. It returns ctx.Err() if ctx is done first.
+func (q *Bounded[T]) Dequeue(ctx context.Context) (T, error) {
+	var v T
+	err := q.notEmpty.wait(ctx, func() (ok bool) {
+		v, ok = q.TryDequeue()
+		return ok
+	})
+	return v, err
+}
@@ -0,0 +1,71 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBounded(t *testing.T) {
+	q := NewBounded[int](3)
+	for i := 0; i < 3; i++ {
+		if !q.TryEnqueue(i) {
+			t.Fatalf("TryEnqueue(%d) on a queue with room failed", i)
+		}
+	}
+	if q.TryEnqueue(3) {
+		t.Fatalf("TryEnqueue() on a full queue succeeded")
+	}
+	if q.Len() != 3 || q.Cap() != 3 {
+		t.Errorf("Len(), Cap() = %d, %d; want 3, 3", q.Len(), q.Cap())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := q.Enqueue(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Enqueue() on a full queue = %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	// wrap around the ring a few times
+	for i := 3; i < 20; i++ {
+		if v, ok := q.TryDequeue(); !ok || v != i-3 {
+			t.Fatalf("TryDequeue() = %d, %v; want %d, true", v, ok, i-3)
+		}
+		if err := q.Enqueue(context.Background(), i); err != nil {
+			t.Fatalf("Enqueue(%d) = %v", i, err)
+		}
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.TryDequeue()
+	}()
+	if err := q.Enqueue(context.Background(), 20); err != nil {
+		t.Errorf("Enqueue() = %v; want it to wait for room", err)
+	}
+	for i := 18; i <= 20; i++ {
+		if v, ok := q.TryDequeue(); !ok || v != i {
+			t.Fatalf("TryDequeue() = %d, %v; want %d, true", v, ok, i)
+		}
+	}
+	testDequeueWaits(t, q, func(v int) { q.TryEnqueue(v) })
+}
+
+func TestBoundedParallel(t *testing.T) {
+	// a small ring keeps producers blocked on a full queue most of the time
+	q := NewBounded[int](4)
+	stress(t, q, q.Enqueue)
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d after draining; want 0", q.Len())
+	}
+}
+
+func TestNewBoundedPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewBounded(0) did not panic")
+		}
+	}()
+	NewBounded[int](0)
+}
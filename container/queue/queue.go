@@ -0,0 +1,103 @@
+// package queue implements FIFO queues that are safe for concurrent use by
+// any number of producers and consumers.
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type node[T any] struct {
+	value T
+	next  atomic.Pointer[node[T]]
+}
+
+// Queue is an unbounded lock-free queue (Michael and Scott, "Simple, Fast,
+// and Practical Non-Blocking and Blocking Concurrent Queue Algorithms").
+// head always points at a dummy node whose successor holds the front value,
+// so producers and consumers never touch the same pointer on a non-empty
+// queue. The zero value is not usable, create one with New.
+type Queue[T any] struct {
+	head     atomic.Pointer[node[T]]
+	tail     atomic.Pointer[node[T]]
+	len      atomic.Int64
+	notEmpty waiter
+}
+
+// New returns an empty queue.
+func New[T any]() *Queue[T] {
+	q := &Queue[T]{notEmpty: newWaiter()}
+	dummy := &node[T]{}
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+// Len returns the number of values in the queue. It is exact only while no
+// other goroutine uses the queue.
+func (q *Queue[T]) Len() int {
+	return int(max(q.len.Load(), 0))
+}
+
+// Enqueue adds v to the back of the queue. It never blocks.
+func (q *Queue[T]) Enqueue(v T) {
+	n := &node[T]{value: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+		if next != nil {
+			// tail is lagging behind, help the producer that linked next
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			break
+		}
+	}
+	q.len.Add(1)
+	q.notEmpty.wake()
+}
+
+// TryDequeue removes and returns the front value, the bool is false if the
+// queue is empty. It never blocks.
+func (q *Queue[T]) TryDequeue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+		if head == tail {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		// next becomes the dummy and keeps its value until the next
+		// dequeue, clearing it here would race with consumers that read
+		// it before losing the CAS below
+		v := next.value
+		if q.head.CompareAndSwap(head, next) {
+			q.len.Add(-1)
+			return v, true
+		}
+	}
+}
+
+// Dequeue removes and returns the front value, waiting for one to be
+// enqueued if the queue is empty. It returns ctx.Err() if ctx is done first.
+func (q *Queue[T]) Dequeue(ctx context.Context) (T, error) {
+	var v T
+	err := q.notEmpty.wait(ctx, func() (ok bool) {
+		v, ok = q.TryDequeue()
+		return ok
+	})
+	return v, err
+}
@@ -0,0 +1,187 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golabs/container/listx"
+)
+
+// fifo is what both queues offer to the tests and benchmarks below.
+type fifo interface {
+	TryDequeue() (int, bool)
+	Dequeue(ctx context.Context) (int, error)
+}
+
+func TestQueue(t *testing.T) {
+	q := New[int]()
+	if _, ok := q.TryDequeue(); ok {
+		t.Fatalf("TryDequeue() on an empty queue found a value")
+	}
+	for i := 0; i < 10; i++ {
+		q.Enqueue(i)
+	}
+	if q.Len() != 10 {
+		t.Errorf("Len() = %d; want 10", q.Len())
+	}
+	for i := 0; i < 10; i++ {
+		if v, ok := q.TryDequeue(); !ok || v != i {
+			t.Fatalf("TryDequeue() = %d, %v; want %d, true", v, ok, i)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", q.Len())
+	}
+	testDequeueWaits(t, q, func(v int) { q.Enqueue(v) })
+}
+
+// testDequeueWaits checks that Dequeue blocks until put adds a value and
+// gives up once its context is done.
+func testDequeueWaits(t *testing.T, q fifo, put func(int)) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.Dequeue(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Dequeue() on an empty queue = %v; want %v", err, context.DeadlineExceeded)
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		put(42)
+	}()
+	if v, err := q.Dequeue(context.Background()); err != nil || v != 42 {
+		t.Errorf("Dequeue() = %d, %v; want 42, nil", v, err)
+	}
+}
+
+// stress runs producers that each enqueue ops values tagged with their id
+// against consumers that dequeue until every value arrived. Every value
+// must arrive exactly once, and the values of one producer in the order it
+// enqueued them.
+func stress(t *testing.T, q fifo, put func(context.Context, int) error) {
+	const producers, consumers, ops = 4, 4, 5000
+	n := ops
+	if testing.Short() {
+		n = 500
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				if err := put(ctx, p*n+i); err != nil {
+					t.Errorf("producer %d: %v", p, err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	var received atomic.Int64
+	seen := make([]atomic.Int32, producers*n)
+	for c := 0; c < consumers; c++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			last := make([]int, producers)
+			for i := range last {
+				last[i] = -1
+			}
+			for received.Load() < producers*int64(n) {
+				var v int
+				var ok bool
+				if c%2 == 0 {
+					v, ok = q.TryDequeue()
+				} else {
+					waitCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+					var err error
+					v, err = q.Dequeue(waitCtx)
+					cancel()
+					ok = err == nil
+				}
+				if !ok {
+					continue
+				}
+				received.Add(1)
+				if seen[v].Add(1) != 1 {
+					t.Errorf("value %d dequeued twice", v)
+				}
+				if p, i := v/n, v%n; i <= last[p] {
+					t.Errorf("value %d of producer %d dequeued after %d", i, p, last[p])
+				} else {
+					last[p] = i
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	for v := range seen {
+		if seen[v].Load() != 1 {
+			t.Fatalf("value %d dequeued %d times", v, seen[v].Load())
+		}
+	}
+}
+
+func TestQueueParallel(t *testing.T) {
+	q := New[int]()
+	stress(t, q, func(_ context.Context, v int) error {
+		q.Enqueue(v)
+		return nil
+	})
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d after draining; want 0", q.Len())
+	}
+}
+
+// lockedList is the baseline for the benchmarks: a listx.List behind a mutex.
+type lockedList struct {
+	mu   sync.Mutex
+	list listx.List[int]
+}
+
+func (l *lockedList) Enqueue(v int) {
+	l.mu.Lock()
+	l.list.PushBack(v)
+	l.mu.Unlock()
+}
+
+func (l *lockedList) TryDequeue() (int, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e := l.list.Front(); e != nil {
+		return l.list.Remove(e), true
+	}
+	return 0, false
+}
+
+func BenchmarkQueue(b *testing.B) {
+	q, bq, l := New[int](), NewBounded[int](1024), &lockedList{}
+	impls := []struct {
+		name    string
+		enqueue func(int)
+		dequeue func() (int, bool)
+	}{
+		{"Queue", q.Enqueue, q.TryDequeue},
+		{"Bounded", func(v int) { bq.TryEnqueue(v) }, bq.TryDequeue},
+		{"LockedList", l.Enqueue, l.TryDequeue},
+	}
+	for _, impl := range impls {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					impl.enqueue(i)
+					impl.dequeue()
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package queue
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// waiter parks goroutines until another goroutine reports that the
+// operation they wait for may now succeed. Wakeups are coalesced into one
+// token, so every goroutine that succeeds after a wakeup passes the token
+// on while others are still parked.
+type waiter struct {
+	n  atomic.Int32
+	ch chan struct{}
+}
+
+func newWaiter() waiter {
+	return waiter{ch: make(chan struct{}, 1)}
+}
+
+// wake lets one parked goroutine retry.
+func (w *waiter) wake() {
+	if w.n.Load() > 0 {
+		select {
+		case w.ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// wait calls try until it succeeds or ctx is done. Registering before the
+// last try means a wake that races with it cannot be lost.
+func (w *waiter) wait(ctx context.Context, try func() bool) error {
+	if try() {
+		return nil
+	}
+	for {
+		w.n.Add(1)
+		if try() {
+			w.n.Add(-1)
+			w.wake()
+			return nil
+		}
+		select {
+		case <-w.ch:
+			w.n.Add(-1)
+		case <-ctx.Done():
+			w.n.Add(-1)
+			return ctx.Err()
+		}
+		if try() {
+			w.wake()
+			return nil
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Monoid describes a subtree aggregate. Combine must be associative and
+// Identity must leave any value unchanged when combined with it. Combine
+// is always called with the smaller keys on the left, so it does not need
+// to be commutative.
+type Monoid[A any] struct {
+	Identity A
+	Combine  func(a, b A) A
+}
+
+// Number is the set of types SumMonoid can add up.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// SumMonoid returns the monoid that adds values up.
+func SumMonoid[A Number]() Monoid[A] {
+	return Monoid[A]{Combine: func(a, b A) A { return a + b }}
+}
+
+// MinMonoid returns the monoid that keeps the smallest value,
+// identity must not be less than any value that is aggregated.
+func MinMonoid[A cmp.Ordered](identity A) Monoid[A] {
+	return Monoid[A]{Identity: identity, Combine: func(a, b A) A { return min(a, b) }}
+}
+
+// MaxMonoid returns the monoid that keeps the largest value,
+// identity must not be greater than any value that is aggregated.
+func MaxMonoid[A cmp.Ordered](identity A) Monoid[A] {
+	return Monoid[A]{Identity: identity, Combine: func(a, b A) A { return max(a, b) }}
+}
+
+type augEntry[K, V, A any] struct {
+	key   K
+	value V
+	// agg is the aggregate of the subtree rooted at the node holding the entry.
+	agg A
+}
+
+// AugmentedMap is an ordered key/value map whose nodes also keep the
+// aggregate of the values in their subtree. The aggregates are kept up to
+// date by every rotation and fix-up of the tree, in the same places as the
+// subtree sizes, so any key range can be aggregated in O(log n).
+type AugmentedMap[K, V, A any] struct {
+	tree    *RBTree[augEntry[K, V, A]]
+	monoid  Monoid[A]
+	measure func(key K, value V) A
+}
+
+// NewAugmentedMap returns an empty map ordered by cmp.Compare. measure maps
+// a single entry to the aggregate that monoid combines.
+func NewAugmentedMap[K cmp.Ordered, V, A any](monoid Monoid[A], measure func(key K, value V) A, opts ...Option) *AugmentedMap[K, V, A] {
+	return NewAugmentedMapFunc(cmp.Compare[K], monoid, measure, opts...)
+}
+
+// NewAugmentedMapFunc is NewAugmentedMap with keys ordered by cmp.
+func NewAugmentedMapFunc[K, V, A any](cmp func(a, b K) int, monoid Monoid[A], measure func(key K, value V) A, opts ...Option) *AugmentedMap[K, V, A] {
+	m := &AugmentedMap[K, V, A]{monoid: monoid, measure: measure}
+	m.tree = NewFunc(func(a, b augEntry[K, V, A]) int {
+		return cmp(a.key, b.key)
+	}, opts...)
+	m.tree.augment = m.pull
+	return m
+}
+
+func (m *AugmentedMap[K, V, A]) aggOf(n *RBNode[augEntry[K, V, A]]) A {
+	if n == nil {
+		return m.monoid.Identity
+	}
+	return n.key.agg
+}
+
+// pull recomputes the aggregate of n from its entry and its children.
+func (m *AugmentedMap[K, V, A]) pull(n *RBNode[augEntry[K, V, A]]) {
+	agg := m.monoid.Combine(m.aggOf(n.getChild(DIR_LEFT)), m.measure(n.key.key, n.key.value))
+	n.key.agg = m.monoid.Combine(agg, m.aggOf(n.getChild(DIR_RIGHT)))
+}
+
+// Len returns the number of keys in the map.
+func (m *AugmentedMap[K, V, A]) Len() int {
+	return m.tree.Len()
+}
+
+// Put associates value with key and reports whether key was already present.
+func (m *AugmentedMap[K, V, A]) Put(key K, value V) bool {
+	if n := m.tree.Find(augEntry[K, V, A]{key: key}); n != nil {
+		n.key.value = value
+		for ; n != nil; n = n.parent {
+			m.pull(n)
+		}
+		return true
+	}
+	m.tree.Insert(augEntry[K, V, A]{key: key, value: value})
+	return false
+}
+
+// Get returns the value associated with key and whether it was found.
+func (m *AugmentedMap[K, V, A]) Get(key K) (V, bool) {
+	if n := m.tree.Find(augEntry[K, V, A]{key: key}); n != nil {
+		return n.key.value, true
+	}
+	var v V
+	return v, false
+}
+
+// Contains reports whether key is in the map.
+func (m *AugmentedMap[K, V, A]) Contains(key K) bool {
+	return m.tree.Contains(augEntry[K, V, A]{key: key})
+}
+
+// Delete removes key from the map and reports whether it was present.
+func (m *AugmentedMap[K, V, A]) Delete(key K) bool {
+	return m.tree.EraseKey(augEntry[K, V, A]{key: key})
+}
+
+// All returns an iterator over the key/value pairs in ascending key order.
+func (m *AugmentedMap[K, V, A]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range m.tree.All() {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Aggregate returns the aggregate of every value in the map in O(1).
+func (m *AugmentedMap[K, V, A]) Aggregate() A {
+	return m.aggOf(m.tree.root)
+}
+
+// RangeAggregate returns the aggregate of the values whose key lies between
+// lo and hi in O(log n), see RBTree.Range for the meaning of mode.
+func (m *AugmentedMap[K, V, A]) RangeAggregate(lo, hi K, mode RangeMode) A {
+	cmp := m.tree.cmp
+	loE, hiE := augEntry[K, V, A]{key: lo}, augEntry[K, V, A]{key: hi}
+	// aboveLo and belowHi tell whether the whole subtree is known to be on
+	// the right side of lo and hi, once both hold the stored aggregate is used.
+	var aggregate func(n *RBNode[augEntry[K, V, A]], aboveLo, belowHi bool) A
+	aggregate = func(n *RBNode[augEntry[K, V, A]], aboveLo, belowHi bool) A {
+		if n == nil {
+			return m.monoid.Identity
+		}
+		if aboveLo && belowHi {
+			return n.key.agg
+		}
+		if !aboveLo {
+			if c := cmp(n.key, loE); c < 0 || c == 0 && mode.loOpen() {
+				return aggregate(n.getChild(DIR_RIGHT), aboveLo, belowHi)
+			}
+		}
+		if !belowHi {
+			if c := cmp(n.key, hiE); c > 0 || c == 0 && mode.hiOpen() {
+				return aggregate(n.getChild(DIR_LEFT), aboveLo, belowHi)
+			}
+		}
+		l := aggregate(n.getChild(DIR_LEFT), aboveLo, true)
+		r := aggregate(n.getChild(DIR_RIGHT), true, belowHi)
+		return m.monoid.Combine(m.monoid.Combine(l, m.measure(n.key.key, n.key.value)), r)
+	}
+	return aggregate(m.tree.root, false, false)
+}
@@ -0,0 +1,83 @@
+package rbtree
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestAugmentedMapSum(t *testing.T) {
+	m := NewAugmentedMap(SumMonoid[int](), func(_ int, v int) int { return v })
+	ref := make(map[int]int)
+	for i := 0; i < 2000; i++ {
+		k := rand.Intn(200)
+		if rand.Intn(4) == 0 {
+			m.Delete(k)
+			delete(ref, k)
+		} else {
+			v := rand.Intn(100)
+			m.Put(k, v)
+			ref[k] = v
+		}
+	}
+	if err := m.tree.Validate(); err != nil {
+		t.Fatalf("Validate(): %v", err)
+	}
+	sum := func(lo, hi int, mode RangeMode) (s int) {
+		for k, v := range ref {
+			if (k > lo || k == lo && !mode.loOpen()) && (k < hi || k == hi && !mode.hiOpen()) {
+				s += v
+			}
+		}
+		return
+	}
+	if got, want := m.Aggregate(), sum(math.MinInt, math.MaxInt, RANGE_CLOSED); got != want {
+		t.Fatalf("Aggregate() = %d; want %d", got, want)
+	}
+	for i := 0; i < 200; i++ {
+		lo, hi := rand.Intn(220)-10, rand.Intn(220)-10
+		mode := RangeMode(rand.Intn(4))
+		if got, want := m.RangeAggregate(lo, hi, mode), sum(lo, hi, mode); got != want {
+			t.Fatalf("RangeAggregate(%d, %d, %d) = %d; want %d", lo, hi, mode, got, want)
+		}
+	}
+}
+
+func TestAugmentedMapMinMax(t *testing.T) {
+	maxes := NewAugmentedMap(MaxMonoid(math.Inf(-1)), func(_ string, v float64) float64 { return v })
+	mins := NewAugmentedMap(MinMonoid(math.Inf(1)), func(_ string, v float64) float64 { return v })
+	for k, v := range map[string]float64{"a": 3, "b": 9, "c": 1, "d": 7} {
+		maxes.Put(k, v)
+		mins.Put(k, v)
+	}
+	if got := maxes.RangeAggregate("a", "c", RANGE_CLOSED); got != 9 {
+		t.Errorf("max over [a, c] = %v; want 9", got)
+	}
+	if got := maxes.RangeAggregate("c", "d", RANGE_CLOSED); got != 7 {
+		t.Errorf("max over [c, d] = %v; want 7", got)
+	}
+	if got := mins.RangeAggregate("a", "b", RANGE_CLOSED); got != 3 {
+		t.Errorf("min over [a, b] = %v; want 3", got)
+	}
+	if got := mins.RangeAggregate("x", "z", RANGE_CLOSED); !math.IsInf(got, 1) {
+		t.Errorf("min over an empty range = %v; want +Inf", got)
+	}
+	maxes.Put("b", 0)
+	if got := maxes.Aggregate(); got != 7 {
+		t.Errorf("max after lowering b = %v; want 7", got)
+	}
+}
+
+func TestAugmentedMapOrder(t *testing.T) {
+	concat := Monoid[string]{Combine: func(a, b string) string { return a + b }}
+	m := NewAugmentedMap(concat, func(_ int, v string) string { return v })
+	for i, v := range []string{"d", "a", "c", "b", "e"} {
+		m.Put([]int{4, 1, 3, 2, 5}[i], v)
+	}
+	if got := m.Aggregate(); got != "abcde" {
+		t.Errorf("Aggregate() = %q; want abcde", got)
+	}
+	if got := m.RangeAggregate(2, 4, RANGE_CLOSED); got != "bcd" {
+		t.Errorf("RangeAggregate(2, 4) = %q; want bcd", got)
+	}
+}
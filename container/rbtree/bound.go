@@ -0,0 +1,89 @@
+package rbtree
+
+// RangeMode tells Range whether lo and hi belong to the range.
+type RangeMode uint8
+
+const (
+	RANGE_CLOSED     RangeMode = iota // [lo, hi]
+	RANGE_OPEN                        // (lo, hi)
+	RANGE_LEFT_OPEN                   // (lo, hi]
+	RANGE_RIGHT_OPEN                  // [lo, hi)
+)
+
+func (m RangeMode) loOpen() bool {
+	return m == RANGE_OPEN || m == RANGE_LEFT_OPEN
+}
+
+func (m RangeMode) hiOpen() bool {
+	return m == RANGE_OPEN || m == RANGE_RIGHT_OPEN
+}
+
+// upperBound returns the first node whose key is greater than key.
+func (t *RBTree[T]) upperBound(key T) (ans *RBNode[T]) {
+	now := t.root
+	for now != nil {
+		if t.cmp(now.key, key) > 0 {
+			ans = now
+			now = now.getChild(DIR_LEFT)
+		} else {
+			now = now.getChild(DIR_RIGHT)
+		}
+	}
+	return
+}
+
+// Min returns the node holding the smallest key, or nil if the tree is empty.
+func (t *RBTree[T]) Min() *RBNode[T] {
+	return leftmost(t.root)
+}
+
+// Max returns the node holding the largest key, or nil if the tree is empty.
+func (t *RBTree[T]) Max() *RBNode[T] {
+	return rightmost(t.root)
+}
+
+// Ceiling returns the first node whose key is greater than or equal to key,
+// or nil if there is none. It is the lower bound of key.
+func (t *RBTree[T]) Ceiling(key T) *RBNode[T] {
+	return t.lowerBound(key)
+}
+
+// Higher returns the first node whose key is greater than key,
+// or nil if there is none. It is the upper bound of key.
+func (t *RBTree[T]) Higher(key T) *RBNode[T] {
+	return t.upperBound(key)
+}
+
+// Floor returns the last node whose key is less than or equal to key,
+// or nil if there is none.
+func (t *RBTree[T]) Floor(key T) *RBNode[T] {
+	if n := t.upperBound(key); n != nil {
+		return t.prev(n)
+	}
+	return t.Max()
+}
+
+// Lower returns the last node whose key is less than key,
+// or nil if there is none.
+func (t *RBTree[T]) Lower(key T) *RBNode[T] {
+	if n := t.lowerBound(key); n != nil {
+		return t.prev(n)
+	}
+	return t.Max()
+}
+
+// Range calls fn for every node whose key lies between lo and hi in
+// ascending order, mode decides whether lo and hi themselves are included.
+// Range stops as soon as fn returns false.
+func (t *RBTree[T]) Range(lo, hi T, mode RangeMode, fn func(node *RBNode[T]) bool) {
+	n := Ternary(mode.loOpen(), t.upperBound(lo), t.lowerBound(lo))
+	for ; n != nil; n = t.next(n) {
+		c := t.cmp(n.key, hi)
+		if c > 0 || c == 0 && mode.hiOpen() {
+			return
+		}
+		if !fn(n) {
+			return
+		}
+	}
+}
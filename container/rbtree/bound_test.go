@@ -0,0 +1,112 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBounds(t *testing.T) {
+	tree := New[int]()
+	if tree.Min() != nil || tree.Max() != nil || tree.Floor(1) != nil {
+		t.Fatalf("queries on an empty tree should return nil")
+	}
+	for _, k := range []int{50, 10, 30, 20, 40} {
+		tree.Insert(k)
+	}
+	key := func(n *RBNode[int]) int {
+		if n == nil {
+			return -1
+		}
+		return n.Key()
+	}
+	tests := []struct {
+		name string
+		got  *RBNode[int]
+		want int
+	}{
+		{"Min()", tree.Min(), 10},
+		{"Max()", tree.Max(), 50},
+		{"Floor(30)", tree.Floor(30), 30},
+		{"Floor(35)", tree.Floor(35), 30},
+		{"Floor(5)", tree.Floor(5), -1},
+		{"Floor(99)", tree.Floor(99), 50},
+		{"Ceiling(30)", tree.Ceiling(30), 30},
+		{"Ceiling(35)", tree.Ceiling(35), 40},
+		{"Ceiling(55)", tree.Ceiling(55), -1},
+		{"Lower(30)", tree.Lower(30), 20},
+		{"Lower(10)", tree.Lower(10), -1},
+		{"Lower(99)", tree.Lower(99), 50},
+		{"Higher(30)", tree.Higher(30), 40},
+		{"Higher(50)", tree.Higher(50), -1},
+		{"Higher(0)", tree.Higher(0), 10},
+	}
+	for _, tt := range tests {
+		if got := key(tt.got); got != tt.want {
+			t.Errorf("%s = %d; want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	tree := New[int]()
+	for i := 10; i > 0; i-- {
+		tree.Insert(i)
+	}
+	collect := func(lo, hi int, mode RangeMode) []int {
+		var res []int
+		tree.Range(lo, hi, mode, func(n *RBNode[int]) bool {
+			res = append(res, n.Key())
+			return true
+		})
+		return res
+	}
+	tests := []struct {
+		mode RangeMode
+		want []int
+	}{
+		{RANGE_CLOSED, []int{3, 4, 5, 6}},
+		{RANGE_OPEN, []int{4, 5}},
+		{RANGE_LEFT_OPEN, []int{4, 5, 6}},
+		{RANGE_RIGHT_OPEN, []int{3, 4, 5}},
+	}
+	for _, tt := range tests {
+		if got := collect(3, 6, tt.mode); !slices.Equal(got, tt.want) {
+			t.Errorf("Range(3, 6, %d) = %v; want %v", tt.mode, got, tt.want)
+		}
+	}
+	if got := collect(6, 3, RANGE_CLOSED); len(got) != 0 {
+		t.Errorf("Range(6, 3) = %v; want empty", got)
+	}
+
+	var stopped []int
+	tree.Range(1, 10, RANGE_CLOSED, func(n *RBNode[int]) bool {
+		stopped = append(stopped, n.Key())
+		return len(stopped) < 2
+	})
+	if !slices.Equal(stopped, []int{1, 2}) {
+		t.Errorf("Range stopped at %v; want [1 2]", stopped)
+	}
+}
+
+func TestTreeMapBounds(t *testing.T) {
+	m := NewTreeMap[int, string]()
+	m.Put(100, "a")
+	m.Put(200, "b")
+	if k, v, ok := m.Floor(150); !ok || k != 100 || v != "a" {
+		t.Errorf("Floor(150) = %d, %q, %v; want 100, a, true", k, v, ok)
+	}
+	if k, _, ok := m.Higher(100); !ok || k != 200 {
+		t.Errorf("Higher(100) = %d, %v; want 200, true", k, ok)
+	}
+	if _, _, ok := m.Ceiling(201); ok {
+		t.Errorf("Ceiling(201) found a key; want none")
+	}
+	var got []string
+	m.Range(0, 200, RANGE_RIGHT_OPEN, func(_ int, v string) bool {
+		got = append(got, v)
+		return true
+	})
+	if !slices.Equal(got, []string{"a"}) {
+		t.Errorf("Range(0, 200) = %v; want [a]", got)
+	}
+}
@@ -0,0 +1,55 @@
+package rbtree
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+// FromSorted builds a tree from keys sorted in ascending order in O(n),
+// if unique is set repeated keys are stored once.
+func FromSorted[T cmp.Ordered](keys []T, unique bool, opts ...Option) *RBTree[T] {
+	return FromSortedFunc(keys, cmp.Compare[T], unique, opts...)
+}
+
+// FromSortedFunc is FromSorted with keys ordered by cmp.
+// The keys slice is not modified.
+func FromSortedFunc[T any](keys []T, cmp func(a, b T) int, unique bool, opts ...Option) *RBTree[T] {
+	if unique {
+		keys = slices.CompactFunc(slices.Clone(keys), func(a, b T) bool {
+			return cmp(a, b) == 0
+		})
+	}
+	t := NewFunc(cmp, opts...)
+	t.setRoot(t.build(keys))
+	return t
+}
+
+// build returns a balanced subtree holding keys in order, its nodes come
+// from t.newNode.
+func (t *RBTree[T]) build(keys []T) *RBNode[T] {
+	return t.buildBalanced(keys, 0, bits.Len(uint(len(keys)))-1)
+}
+
+// buildBalanced builds a subtree from keys with the middle key as root.
+// Every level above redDepth is full, so colouring the nodes on redDepth
+// red and everything else black gives every path the same black height.
+func (t *RBTree[T]) buildBalanced(keys []T, depth, redDepth int) *RBNode[T] {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	n := t.newNode(keys[mid])
+	l := t.buildBalanced(keys[:mid], depth+1, redDepth)
+	r := t.buildBalanced(keys[mid+1:], depth+1, redDepth)
+	return link(l, n, r, Ternary(depth == redDepth, RED, BLACK))
+}
+
+// ToSlice returns the keys of the tree in ascending order.
+func (t *RBTree[T]) ToSlice() []T {
+	res := make([]T, 0, t.Len())
+	for k := range t.All() {
+		res = append(res, k)
+	}
+	return res
+}
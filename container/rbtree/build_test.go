@@ -0,0 +1,64 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFromSorted(t *testing.T) {
+	for n := 0; n < 130; n++ {
+		keys := make([]int, n)
+		for i := range keys {
+			keys[i] = i
+		}
+		tree := FromSorted(keys, false)
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("FromSorted(%d keys): %v", n, err)
+		}
+		if got := tree.ToSlice(); !slices.Equal(got, keys) {
+			t.Fatalf("ToSlice() = %v; want %v", got, keys)
+		}
+		tree.Insert(n)
+		tree.EraseKey(0)
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("FromSorted(%d keys) after updates: %v", n, err)
+		}
+	}
+}
+
+func TestFromSortedUnique(t *testing.T) {
+	keys := []int{1, 1, 2, 3, 3, 3, 4}
+	if got := FromSorted(keys, false).ToSlice(); !slices.Equal(got, keys) {
+		t.Errorf("FromSorted(unique=false) = %v; want %v", got, keys)
+	}
+	tree := FromSorted(keys, true)
+	if got := tree.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("FromSorted(unique=true) = %v; want [1 2 3 4]", got)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Validate(): %v", err)
+	}
+	if !slices.Equal(keys, []int{1, 1, 2, 3, 3, 3, 4}) {
+		t.Errorf("FromSorted modified its input: %v", keys)
+	}
+}
+
+func BenchmarkFromSorted(b *testing.B) {
+	keys := make([]int, 1<<20)
+	for i := range keys {
+		keys[i] = i
+	}
+	b.Run("FromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			FromSorted(keys, false)
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree := New[int]()
+			for _, k := range keys {
+				tree.Insert(k)
+			}
+		}
+	})
+}
@@ -0,0 +1,259 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	ErrInvalidEncoding = errors.New("invalid tree encoding")
+	ErrNoComparator    = errors.New("tree has no comparator")
+)
+
+// KeyCodec converts keys to and from bytes. Decode must read exactly the
+// bytes Append wrote for one key and return how many it consumed.
+type KeyCodec[T any] struct {
+	Append func(buf []byte, key T) []byte
+	Decode func(buf []byte) (key T, n int, err error)
+}
+
+// IntCodec returns a codec that stores signed integers as varints.
+func IntCodec[T ~int | ~int8 | ~int16 | ~int32 | ~int64]() KeyCodec[T] {
+	return KeyCodec[T]{
+		Append: func(buf []byte, key T) []byte {
+			return binary.AppendVarint(buf, int64(key))
+		},
+		Decode: func(buf []byte) (T, int, error) {
+			v, n := binary.Varint(buf)
+			if n <= 0 {
+				return 0, 0, ErrInvalidEncoding
+			}
+			return T(v), n, nil
+		},
+	}
+}
+
+// UintCodec returns a codec that stores unsigned integers as uvarints.
+func UintCodec[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr]() KeyCodec[T] {
+	return KeyCodec[T]{
+		Append: func(buf []byte, key T) []byte {
+			return binary.AppendUvarint(buf, uint64(key))
+		},
+		Decode: func(buf []byte) (T, int, error) {
+			v, n := binary.Uvarint(buf)
+			if n <= 0 {
+				return 0, 0, ErrInvalidEncoding
+			}
+			return T(v), n, nil
+		},
+	}
+}
+
+// StringCodec returns a codec that stores strings with a uvarint length prefix.
+func StringCodec[T ~string]() KeyCodec[T] {
+	return KeyCodec[T]{
+		Append: func(buf []byte, key T) []byte {
+			buf = binary.AppendUvarint(buf, uint64(len(key)))
+			return append(buf, key...)
+		},
+		Decode: func(buf []byte) (T, int, error) {
+			l, n := binary.Uvarint(buf)
+			if n <= 0 || uint64(len(buf)-n) < l {
+				return "", 0, ErrInvalidEncoding
+			}
+			return T(buf[n : n+int(l)]), n + int(l), nil
+		},
+	}
+}
+
+// Encode writes the length of the payload in bytes as a uvarint followed by
+// the payload: the number of keys as a uvarint and every key in ascending
+// order, each written by codec.
+func (t *RBTree[T]) Encode(w io.Writer, codec KeyCodec[T]) error {
+	payload := binary.AppendUvarint(nil, uint64(t.Len()))
+	for k := range t.All() {
+		payload = codec.Append(payload, k)
+	}
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(payload)))); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// byteReader reads single bytes from r without reading ahead, so that
+// whatever follows the length prefix stays in r.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	_, err := io.ReadFull(b.r, b.buf[:])
+	return b.buf[0], err
+}
+
+// Decode replaces the keys of t with the keys written by Encode. It reads
+// exactly one encoded tree from r, so trees can be embedded in a larger
+// stream. The tree is rebuilt in O(n) without rebalancing, and the keys
+// are checked to be in ascending order.
+func (t *RBTree[T]) Decode(r io.Reader, codec KeyCodec[T]) error {
+	if t.cmp == nil {
+		return ErrNoComparator
+	}
+	keys, err := decodeKeys(r, codec)
+	if err != nil {
+		return err
+	}
+	return t.rebuild(keys, false)
+}
+
+// decodeKeys reads the keys of one tree written by Encode from r.
+func decodeKeys[T any](r io.Reader, codec KeyCodec[T]) ([]T, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = &byteReader{r: r}
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad length: %v", ErrInvalidEncoding, err)
+	}
+	// copy rather than allocate size bytes up front, a corrupt length
+	// must not make us allocate more than the stream holds
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, r, int64(size)); err != nil {
+		return nil, fmt.Errorf("%w: short payload: %v", ErrInvalidEncoding, err)
+	}
+	data := payload.Bytes()
+
+	cnt, n := binary.Uvarint(data)
+	if n <= 0 || cnt > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: bad key count", ErrInvalidEncoding)
+	}
+	data = data[n:]
+	keys := make([]T, 0, cnt)
+	for i := uint64(0); i < cnt; i++ {
+		k, n, err := codec.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %v", ErrInvalidEncoding, i, err)
+		}
+		data = data[n:]
+		keys = append(keys, k)
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data))
+	}
+	return keys, nil
+}
+
+// rebuild replaces the keys of t with keys, which must be sorted, and
+// without repeats if unique is set.
+func (t *RBTree[T]) rebuild(keys []T, unique bool) error {
+	if t.cmp == nil {
+		return ErrNoComparator
+	}
+	for i := 1; i < len(keys); i++ {
+		c := t.cmp(keys[i-1], keys[i])
+		if c > 0 {
+			return fmt.Errorf("%w: keys are not sorted", ErrInvalidEncoding)
+		}
+		if c == 0 && unique {
+			return fmt.Errorf("%w: repeated key in a set", ErrInvalidEncoding)
+		}
+	}
+	t.setRoot(t.build(keys))
+	if t.augment != nil {
+		t.PostOrder(t.augment)
+	}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with encoding/gob,
+// which works for any key type gob can encode. Use Encode with a KeyCodec
+// for a more compact stream.
+func (t *RBTree[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(t.ToSlice()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The tree must have
+// been created with New or NewFunc so that it knows its ordering, otherwise
+// ErrNoComparator is returned.
+func (t *RBTree[T]) UnmarshalBinary(data []byte) error {
+	if t.cmp == nil {
+		return ErrNoComparator
+	}
+	var keys []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&keys); err != nil {
+		return err
+	}
+	return t.rebuild(keys, false)
+}
+
+// MarshalJSON encodes the tree as a JSON array of its keys in ascending order.
+func (t *RBTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.ToSlice())
+}
+
+// UnmarshalJSON replaces the keys of t with a sorted JSON array of keys.
+// The tree must have been created with New or NewFunc, otherwise
+// ErrNoComparator is returned.
+func (t *RBTree[T]) UnmarshalJSON(data []byte) error {
+	if t.cmp == nil {
+		return ErrNoComparator
+	}
+	var keys []T
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	return t.rebuild(keys, false)
+}
+
+// The decoders of RBTree would let a set hold equal keys, so TreeSet has its
+// own that reject them with ErrInvalidEncoding.
+
+// Decode is RBTree.Decode for a set, the keys must be strictly ascending.
+func (s *TreeSet[T]) Decode(r io.Reader, codec KeyCodec[T]) error {
+	if s.RBTree == nil || s.cmp == nil {
+		return ErrNoComparator
+	}
+	keys, err := decodeKeys(r, codec)
+	if err != nil {
+		return err
+	}
+	return s.rebuild(keys, true)
+}
+
+// UnmarshalBinary is RBTree.UnmarshalBinary for a set, the keys must be
+// strictly ascending.
+func (s *TreeSet[T]) UnmarshalBinary(data []byte) error {
+	if s.RBTree == nil || s.cmp == nil {
+		return ErrNoComparator
+	}
+	var keys []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&keys); err != nil {
+		return err
+	}
+	return s.rebuild(keys, true)
+}
+
+// UnmarshalJSON is RBTree.UnmarshalJSON for a set, the keys must be
+// strictly ascending.
+func (s *TreeSet[T]) UnmarshalJSON(data []byte) error {
+	if s.RBTree == nil || s.cmp == nil {
+		return ErrNoComparator
+	}
+	var keys []T
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return err
+	}
+	return s.rebuild(keys, true)
+}
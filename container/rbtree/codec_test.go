@@ -0,0 +1,175 @@
+package rbtree
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	ints := FromSorted([]int{-300, -1, 0, 1, 1, 70000}, false)
+	var buf bytes.Buffer
+	if err := ints.Encode(&buf, IntCodec[int]()); err != nil {
+		t.Fatalf("Encode(): %v", err)
+	}
+	decoded := New[int]()
+	decoded.Insert(42)
+	if err := decoded.Decode(&buf, IntCodec[int]()); err != nil {
+		t.Fatalf("Decode(): %v", err)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Fatalf("Validate(): %v", err)
+	}
+	if got, want := decoded.ToSlice(), ints.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("decoded keys = %v; want %v", got, want)
+	}
+
+	words := New[string]()
+	for _, w := range strings.Fields("the quick brown fox jumps over the lazy dog") {
+		words.Insert(w)
+	}
+	buf.Reset()
+	if err := words.Encode(&buf, StringCodec[string]()); err != nil {
+		t.Fatalf("Encode(): %v", err)
+	}
+	data := bytes.Clone(buf.Bytes())
+	decodedWords := New[string]()
+	if err := decodedWords.Decode(&buf, StringCodec[string]()); err != nil {
+		t.Fatalf("Decode(): %v", err)
+	}
+	if got, want := decodedWords.ToSlice(), words.ToSlice(); !slices.Equal(got, want) {
+		t.Errorf("decoded words = %v; want %v", got, want)
+	}
+
+	// a payload shorter than its length, a key count larger than the
+	// payload holds and a payload with a byte after the last key
+	long := append([]byte{data[0] + 1}, append(data[1:], 0)...)
+	for _, bad := range [][]byte{data[:len(data)-1], {1, 5}, long} {
+		if err := New[string]().Decode(bytes.NewReader(bad), StringCodec[string]()); !errors.Is(err, ErrInvalidEncoding) {
+			t.Errorf("Decode(%q) = %v; want ErrInvalidEncoding", bad, err)
+		}
+	}
+	unsorted := UintCodec[uint8]().Append([]byte{3, 2}, 9)
+	unsorted = UintCodec[uint8]().Append(unsorted, 3)
+	if err := New[uint8]().Decode(bytes.NewReader(unsorted), UintCodec[uint8]()); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Decode(unsorted) = %v; want ErrInvalidEncoding", err)
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	// two trees and a trailer in one stream, each Decode must stop at the
+	// end of its own tree
+	var buf bytes.Buffer
+	a, b := FromSorted([]int{1, 2, 3}, true), FromSorted([]int{-5, 500}, true)
+	for _, tree := range []*RBTree[int]{a, b} {
+		if err := tree.Encode(&buf, IntCodec[int]()); err != nil {
+			t.Fatalf("Encode(): %v", err)
+		}
+	}
+	buf.WriteString("end")
+	r := io.MultiReader(&buf) // hides ReadByte
+	for _, want := range []*RBTree[int]{a, b} {
+		got := New[int]()
+		if err := got.Decode(r, IntCodec[int]()); err != nil {
+			t.Fatalf("Decode(): %v", err)
+		}
+		if !slices.Equal(got.ToSlice(), want.ToSlice()) {
+			t.Errorf("Decode() = %v; want %v", got.ToSlice(), want.ToSlice())
+		}
+	}
+	if rest, _ := io.ReadAll(r); string(rest) != "end" {
+		t.Errorf("rest of the stream = %q; want %q", rest, "end")
+	}
+}
+
+func TestNoComparator(t *testing.T) {
+	var v struct{ T *RBTree[int] }
+	if err := json.Unmarshal([]byte(`{"T":[1,2]}`), &v); !errors.Is(err, ErrNoComparator) {
+		t.Errorf("json.Unmarshal() = %v; want ErrNoComparator", err)
+	}
+	var tree RBTree[int]
+	if err := tree.UnmarshalBinary(nil); !errors.Is(err, ErrNoComparator) {
+		t.Errorf("UnmarshalBinary() = %v; want ErrNoComparator", err)
+	}
+	if err := tree.Decode(bytes.NewReader([]byte{1, 0}), IntCodec[int]()); !errors.Is(err, ErrNoComparator) {
+		t.Errorf("Decode() = %v; want ErrNoComparator", err)
+	}
+}
+
+func TestTreeSetDecode(t *testing.T) {
+	set := NewTreeSet[int]()
+	if err := json.Unmarshal([]byte("[1,2,3]"), set); err != nil || set.Len() != 3 {
+		t.Fatalf("json.Unmarshal([1,2,3]) = %v with %d keys; want nil with 3", err, set.Len())
+	}
+	if err := json.Unmarshal([]byte("[1,1,2]"), set); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("json.Unmarshal([1,1,2]) = %v; want ErrInvalidEncoding", err)
+	}
+
+	dup := FromSorted([]int{4, 4, 5}, false)
+	data, err := dup.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	if err := set.UnmarshalBinary(data); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("UnmarshalBinary([4 4 5]) = %v; want ErrInvalidEncoding", err)
+	}
+	var buf bytes.Buffer
+	if err := dup.Encode(&buf, IntCodec[int]()); err != nil {
+		t.Fatalf("Encode(): %v", err)
+	}
+	if err := set.Decode(&buf, IntCodec[int]()); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Decode([4 4 5]) = %v; want ErrInvalidEncoding", err)
+	}
+	if got := set.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("a failed decode changed the set to %v; want [1 2 3]", got)
+	}
+
+	var zero struct{ S *TreeSet[int] }
+	if err := json.Unmarshal([]byte(`{"S":[1]}`), &zero); !errors.Is(err, ErrNoComparator) {
+		t.Errorf("json.Unmarshal into a zero TreeSet = %v; want ErrNoComparator", err)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	type point struct{ X, Y int }
+	cmpPoint := func(a, b point) int { return a.X - b.X }
+	tree := NewFunc(cmpPoint)
+	tree.Insert(point{3, 1})
+	tree.Insert(point{1, 2})
+	data, err := tree.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): %v", err)
+	}
+	decoded := NewFunc(cmpPoint)
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary(): %v", err)
+	}
+	if got := decoded.ToSlice(); !slices.Equal(got, []point{{1, 2}, {3, 1}}) {
+		t.Errorf("decoded = %v; want [{1 2} {3 1}]", got)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	tree := New[int]()
+	for _, k := range []int{3, 1, 2} {
+		tree.Insert(k)
+	}
+	data, err := tree.MarshalJSON()
+	if err != nil || string(data) != "[1,2,3]" {
+		t.Fatalf("MarshalJSON() = %s, %v; want [1,2,3]", data, err)
+	}
+	decoded := New[int]()
+	if err := decoded.UnmarshalJSON([]byte("[4,5,6]")); err != nil {
+		t.Fatalf("UnmarshalJSON(): %v", err)
+	}
+	if got := decoded.ToSlice(); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("decoded = %v; want [4 5 6]", got)
+	}
+	if err := decoded.UnmarshalJSON([]byte("[2,1]")); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("UnmarshalJSON([2,1]) = %v; want ErrInvalidEncoding", err)
+	}
+}
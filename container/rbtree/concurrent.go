@@ -0,0 +1,187 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentTree is an ordered index shared by many goroutines.
+// Readers run in parallel and writers are serialized. In copy-on-write mode
+// the keys live in a PersistentTree that is swapped atomically after every
+// update, so reads never take the lock and a reader can hold a consistent
+// Snapshot for as long as it likes.
+type ConcurrentTree[T any] struct {
+	mu   sync.RWMutex
+	tree *RBTree[T]
+
+	cow bool
+	cur atomic.Pointer[PersistentTree[T]]
+}
+
+// NewConcurrent returns an empty tree ordered by cmp.Compare,
+// cow selects the copy-on-write mode.
+func NewConcurrent[T cmp.Ordered](cow bool) *ConcurrentTree[T] {
+	return NewConcurrentFunc(cmp.Compare[T], cow)
+}
+
+// NewConcurrentFunc returns an empty tree ordered by cmp,
+// cow selects the copy-on-write mode.
+func NewConcurrentFunc[T any](cmp func(a, b T) int, cow bool) *ConcurrentTree[T] {
+	c := &ConcurrentTree[T]{cow: cow}
+	if cow {
+		c.cur.Store(NewPersistentFunc(cmp))
+	} else {
+		c.tree = NewFunc(cmp)
+	}
+	return c
+}
+
+// view runs fn on the current keys. fn must only use the queries that walk
+// down from the root, since copy-on-write nodes carry no parent links.
+func (c *ConcurrentTree[T]) view(fn func(t *RBTree[T])) {
+	if c.cow {
+		fn(&c.cur.Load().tree)
+		return
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	fn(c.tree)
+}
+
+// Len returns the number of keys in the tree.
+func (c *ConcurrentTree[T]) Len() (n int) {
+	c.view(func(t *RBTree[T]) { n = t.Len() })
+	return
+}
+
+// Contains reports whether key is in the tree.
+func (c *ConcurrentTree[T]) Contains(key T) (ok bool) {
+	c.view(func(t *RBTree[T]) { ok = t.Contains(key) })
+	return
+}
+
+// Get returns the first key equal to key and whether there is one.
+func (c *ConcurrentTree[T]) Get(key T) (k T, ok bool) {
+	c.view(func(t *RBTree[T]) { k, ok = keyOf(t.Find(key)) })
+	return
+}
+
+// Floor returns the greatest key less than or equal to key.
+func (c *ConcurrentTree[T]) Floor(key T) (k T, ok bool) {
+	c.view(func(t *RBTree[T]) { k, ok = keyOf(t.Select(t.rank(key, true) - 1)) })
+	return
+}
+
+// Ceiling returns the least key greater than or equal to key.
+func (c *ConcurrentTree[T]) Ceiling(key T) (k T, ok bool) {
+	c.view(func(t *RBTree[T]) { k, ok = keyOf(t.Ceiling(key)) })
+	return
+}
+
+// Rank returns the number of keys less than key.
+func (c *ConcurrentTree[T]) Rank(key T) (n int) {
+	c.view(func(t *RBTree[T]) { n = t.Rank(key) })
+	return
+}
+
+// Select returns the k-th smallest key, counting from 0,
+// and whether k is in range.
+func (c *ConcurrentTree[T]) Select(k int) (key T, ok bool) {
+	c.view(func(t *RBTree[T]) { key, ok = keyOf(t.Select(k)) })
+	return
+}
+
+// All returns an iterator over the keys in ascending order. Without
+// copy-on-write it holds the read lock until the loop ends, so the loop
+// body must not update the tree.
+func (c *ConcurrentTree[T]) All() iter.Seq[T] {
+	if c.cow {
+		return func(yield func(T) bool) {
+			c.cur.Load().All()(yield)
+		}
+	}
+	return func(yield func(T) bool) {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		c.tree.All()(yield)
+	}
+}
+
+// Snapshot returns an immutable copy of the current keys. It is O(1) in
+// copy-on-write mode and O(n) otherwise.
+func (c *ConcurrentTree[T]) Snapshot() *PersistentTree[T] {
+	if c.cow {
+		return c.cur.Load()
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return &PersistentTree[T]{*FromSortedFunc(c.tree.ToSlice(), c.tree.cmp, false)}
+}
+
+// Batch is the handle a Update callback applies its changes through.
+type Batch[T any] struct {
+	tree       *RBTree[T]
+	persistent *PersistentTree[T]
+}
+
+// Insert adds key to the tree.
+func (b *Batch[T]) Insert(key T) {
+	if b.persistent != nil {
+		b.persistent = b.persistent.Insert(key)
+		return
+	}
+	b.tree.Insert(key)
+}
+
+// EraseKey removes one key equal to key and reports whether one was found.
+func (b *Batch[T]) EraseKey(key T) (ok bool) {
+	if b.persistent != nil {
+		b.persistent, ok = b.persistent.Delete(key)
+		return
+	}
+	return b.tree.EraseKey(key)
+}
+
+// Contains reports whether key is in the tree, including the changes
+// made earlier in the batch.
+func (b *Batch[T]) Contains(key T) bool {
+	if b.persistent != nil {
+		return b.persistent.Contains(key)
+	}
+	return b.tree.Contains(key)
+}
+
+// Len returns the number of keys, including the changes made earlier in the batch.
+func (b *Batch[T]) Len() int {
+	if b.persistent != nil {
+		return b.persistent.Len()
+	}
+	return b.tree.Len()
+}
+
+// Update runs fn under the write lock. Readers observe either none or
+// all of the changes fn makes.
+func (c *ConcurrentTree[T]) Update(fn func(b *Batch[T])) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cow {
+		b := &Batch[T]{persistent: c.cur.Load()}
+		fn(b)
+		c.cur.Store(b.persistent)
+		return
+	}
+	fn(&Batch[T]{tree: c.tree})
+}
+
+// Insert adds key to the tree.
+func (c *ConcurrentTree[T]) Insert(key T) {
+	c.Update(func(b *Batch[T]) { b.Insert(key) })
+}
+
+// EraseKey removes one key equal to key and reports whether one was found.
+func (c *ConcurrentTree[T]) EraseKey(key T) (ok bool) {
+	c.Update(func(b *Batch[T]) { ok = b.EraseKey(key) })
+	return
+}
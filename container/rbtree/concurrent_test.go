@@ -0,0 +1,90 @@
+package rbtree
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentTree(t *testing.T) {
+	for _, cow := range []bool{false, true} {
+		c := NewConcurrent[int](cow)
+		for _, k := range []int{4, 2, 6} {
+			c.Insert(k)
+		}
+		if got := slices.Collect(c.All()); !slices.Equal(got, []int{2, 4, 6}) {
+			t.Errorf("cow=%v: All() = %v; want [2 4 6]", cow, got)
+		}
+		if k, ok := c.Floor(5); !ok || k != 4 {
+			t.Errorf("cow=%v: Floor(5) = %d, %v; want 4, true", cow, k, ok)
+		}
+		if _, ok := c.Floor(1); ok {
+			t.Errorf("cow=%v: Floor(1) found a key; want none", cow)
+		}
+		if k, ok := c.Ceiling(5); !ok || k != 6 {
+			t.Errorf("cow=%v: Ceiling(5) = %d, %v; want 6, true", cow, k, ok)
+		}
+		if c.Rank(6) != 2 {
+			t.Errorf("cow=%v: Rank(6) = %d; want 2", cow, c.Rank(6))
+		}
+		snap := c.Snapshot()
+		c.Update(func(b *Batch[int]) {
+			b.EraseKey(2)
+			b.Insert(8)
+			if b.Contains(2) || b.Len() != 3 {
+				t.Errorf("cow=%v: batch does not see its own changes", cow)
+			}
+		})
+		if got := slices.Collect(snap.All()); !slices.Equal(got, []int{2, 4, 6}) {
+			t.Errorf("cow=%v: snapshot = %v; want [2 4 6]", cow, got)
+		}
+		if got := slices.Collect(c.All()); !slices.Equal(got, []int{4, 6, 8}) {
+			t.Errorf("cow=%v: All() after Update = %v; want [4 6 8]", cow, got)
+		}
+		if !c.EraseKey(4) || c.EraseKey(4) || c.Contains(4) {
+			t.Errorf("cow=%v: EraseKey(4) should succeed exactly once", cow)
+		}
+	}
+}
+
+// TestConcurrentTreeParallel is meant to be run with -race. Every batch
+// inserts a pair of keys, so readers must never see an odd length.
+func TestConcurrentTreeParallel(t *testing.T) {
+	for _, cow := range []bool{false, true} {
+		c := NewConcurrent[int](cow)
+		var wg sync.WaitGroup
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					c.Update(func(b *Batch[int]) {
+						b.Insert(w*1000 + i)
+						b.Insert(-(w*1000 + i))
+					})
+				}
+			}(w)
+		}
+		for r := 0; r < 4; r++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 200; i++ {
+					if n := c.Len(); n%2 != 0 {
+						t.Errorf("cow=%v: reader saw a half applied batch, Len() = %d", cow, n)
+						return
+					}
+					if s := c.Snapshot(); s.Len()%2 != 0 {
+						t.Errorf("cow=%v: snapshot of a half applied batch", cow)
+						return
+					}
+					c.Contains(i)
+				}
+			}()
+		}
+		wg.Wait()
+		if c.Len() != 4*200*2 {
+			t.Errorf("cow=%v: Len() = %d; want %d", cow, c.Len(), 4*200*2)
+		}
+	}
+}
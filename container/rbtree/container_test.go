@@ -0,0 +1,31 @@
+package rbtree
+
+import (
+	"testing"
+
+	"golabs/container"
+	"golabs/container/containertest"
+)
+
+var (
+	_ container.OrderedMap[int, int] = (*TreeMap[int, int])(nil)
+	_ container.OrderedSet[int]      = (*TreeSet[int])(nil)
+)
+
+func TestOrderedMap(t *testing.T) {
+	containertest.TestOrderedMap(t, func() container.OrderedMap[int, int] {
+		return NewTreeMap[int, int]()
+	})
+}
+
+func TestOrderedSet(t *testing.T) {
+	containertest.TestOrderedSet(t, func() container.OrderedSet[int] {
+		return NewTreeSet[int]()
+	})
+}
+
+func BenchmarkOrderedMap(b *testing.B) {
+	containertest.BenchmarkOrderedMap(b, 100000, func() container.OrderedMap[int, int] {
+		return NewTreeMap[int, int]()
+	})
+}
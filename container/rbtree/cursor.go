@@ -0,0 +1,69 @@
+package rbtree
+
+// Cursor is a bidirectional position in an RBTree.
+// Unlike the iterators, a cursor may erase the key it points to and keep
+// going, because erasing never moves keys between nodes.
+// Inserting into the tree leaves existing cursors valid, erasing through
+// another cursor or EraseKey invalidates a cursor standing on that node.
+type Cursor[T any] struct {
+	tree *RBTree[T]
+	node *RBNode[T]
+}
+
+// Cursor returns a cursor positioned at the smallest key.
+func (t *RBTree[T]) Cursor() *Cursor[T] {
+	return &Cursor[T]{tree: t, node: t.Min()}
+}
+
+// Valid reports whether the cursor points to a key.
+func (c *Cursor[T]) Valid() bool {
+	return c.node != nil
+}
+
+// Key returns the key under the cursor. The cursor must be valid.
+func (c *Cursor[T]) Key() T {
+	return c.node.key
+}
+
+// Node returns the node under the cursor, or nil if the cursor is not valid.
+func (c *Cursor[T]) Node() *RBNode[T] {
+	return c.node
+}
+
+// First moves the cursor to the smallest key and reports whether it is valid.
+func (c *Cursor[T]) First() bool {
+	c.node = c.tree.Min()
+	return c.Valid()
+}
+
+// Last moves the cursor to the largest key and reports whether it is valid.
+func (c *Cursor[T]) Last() bool {
+	c.node = c.tree.Max()
+	return c.Valid()
+}
+
+// Seek moves the cursor to the first key greater than or equal to key
+// and reports whether it is valid.
+func (c *Cursor[T]) Seek(key T) bool {
+	c.node = c.tree.Ceiling(key)
+	return c.Valid()
+}
+
+// Next moves the cursor to the next key and reports whether it is still valid.
+func (c *Cursor[T]) Next() bool {
+	c.node = c.tree.next(c.node)
+	return c.Valid()
+}
+
+// Prev moves the cursor to the previous key and reports whether it is still valid.
+func (c *Cursor[T]) Prev() bool {
+	c.node = c.tree.prev(c.node)
+	return c.Valid()
+}
+
+// Erase removes the key under the cursor and moves the cursor to the next key.
+// It reports whether the cursor is still valid.
+func (c *Cursor[T]) Erase() bool {
+	c.node = c.tree.erase(c.node)
+	return c.Valid()
+}
@@ -0,0 +1,78 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	tree := New[int]()
+	c := tree.Cursor()
+	if c.Valid() || c.Next() || c.Prev() {
+		t.Fatalf("cursor on an empty tree should not be valid")
+	}
+	for i := 1; i <= 5; i++ {
+		tree.Insert(i)
+	}
+	var got []int
+	for ok := c.First(); ok; ok = c.Next() {
+		got = append(got, c.Key())
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("forward walk = %v; want [1 2 3 4 5]", got)
+	}
+	got = got[:0]
+	for ok := c.Last(); ok; ok = c.Prev() {
+		got = append(got, c.Key())
+	}
+	if !slices.Equal(got, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("backward walk = %v; want [5 4 3 2 1]", got)
+	}
+	if !c.Seek(3) || c.Key() != 3 {
+		t.Errorf("Seek(3) should stop at 3")
+	}
+	if c.Seek(6) {
+		t.Errorf("Seek(6) = true; want false")
+	}
+}
+
+func TestCursorErase(t *testing.T) {
+	tree := New[int]()
+	nodes := make(map[int]*RBNode[int])
+	for i := 0; i < 100; i++ {
+		nodes[i] = tree.Insert(i)
+	}
+	c := tree.Cursor()
+	for c.Valid() {
+		if c.Key()%3 == 0 {
+			c.Erase()
+		} else {
+			c.Next()
+		}
+	}
+	var want []int
+	for i := 0; i < 100; i++ {
+		if i%3 != 0 {
+			want = append(want, i)
+			if nodes[i].Key() != i {
+				t.Errorf("node of %d now holds %d", i, nodes[i].Key())
+			}
+		}
+	}
+	if got := slices.Collect(tree.All()); !slices.Equal(got, want) {
+		t.Errorf("keys after sweep = %v; want %v", got, want)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Errorf("Validate() after sweep: %v", err)
+	}
+
+	c.Last()
+	if c.Erase() {
+		t.Errorf("Erase() of the largest key = true; want false")
+	}
+	for c.First(); c.Erase(); {
+	}
+	if tree.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", tree.Len())
+	}
+}
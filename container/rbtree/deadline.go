@@ -0,0 +1,134 @@
+package rbtree
+
+import (
+	"cmp"
+	"context"
+	"sync"
+	"time"
+)
+
+type deadline[ID any] struct {
+	at time.Time
+	id ID
+}
+
+// DeadlineIndex keeps ids ordered by the time they expire, earliest first.
+// Every id is scheduled at most once. It is safe for concurrent use and
+// Run drives it from a timer loop.
+type DeadlineIndex[ID cmp.Ordered] struct {
+	mu    sync.Mutex
+	tree  *RBTree[deadline[ID]]
+	nodes map[ID]*RBNode[deadline[ID]]
+	// wake is signalled when the earliest deadline may have moved forward.
+	wake chan struct{}
+}
+
+// NewDeadlineIndex returns an empty index. It keeps a node pool by default,
+// opts are applied after it, so WithArena can replace it.
+func NewDeadlineIndex[ID cmp.Ordered](opts ...Option) *DeadlineIndex[ID] {
+	return &DeadlineIndex[ID]{
+		tree: NewFunc(func(a, b deadline[ID]) int {
+			if c := a.at.Compare(b.at); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.id, b.id)
+		}, append([]Option{WithNodePool()}, opts...)...),
+		nodes: make(map[ID]*RBNode[deadline[ID]]),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Len returns the number of scheduled ids.
+func (d *DeadlineIndex[ID]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.tree.Len()
+}
+
+// Add schedules id to expire at at, replacing its previous deadline if it
+// had one. It reports whether id was already scheduled.
+func (d *DeadlineIndex[ID]) Add(id ID, at time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	n, ok := d.nodes[id]
+	if ok {
+		d.tree.erase(n)
+	}
+	n = d.tree.Insert(deadline[ID]{at, id})
+	d.nodes[id] = n
+	if n == d.tree.Min() {
+		d.notify()
+	}
+	return ok
+}
+
+// Cancel removes id from the index and reports whether it was scheduled.
+func (d *DeadlineIndex[ID]) Cancel(id ID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	n, ok := d.nodes[id]
+	if !ok {
+		return false
+	}
+	delete(d.nodes, id)
+	d.tree.erase(n)
+	return true
+}
+
+// NextDeadline returns the earliest deadline, ok is false if nothing is scheduled.
+func (d *DeadlineIndex[ID]) NextDeadline() (at time.Time, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if n := d.tree.Min(); n != nil {
+		return n.key.at, true
+	}
+	return
+}
+
+// PopExpired removes and returns the ids whose deadline is not after now,
+// earliest first.
+func (d *DeadlineIndex[ID]) PopExpired(now time.Time) []ID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var ids []ID
+	for n := d.tree.Min(); n != nil && !n.key.at.After(now); n = d.tree.erase(n) {
+		ids = append(ids, n.key.id)
+		delete(d.nodes, n.key.id)
+	}
+	return ids
+}
+
+func (d *DeadlineIndex[ID]) notify() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run calls expire with the ids that expire, in deadline order, until ctx
+// is done. It sleeps until the earliest deadline and wakes up early when Add
+// schedules an earlier one. Only one Run should be active per index.
+func (d *DeadlineIndex[ID]) Run(ctx context.Context, expire func(ids []ID)) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		if ids := d.PopExpired(time.Now()); len(ids) > 0 {
+			expire(ids)
+		}
+		wait := time.Hour
+		if at, ok := d.NextDeadline(); ok {
+			wait = time.Until(at)
+		}
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.wake:
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package rbtree
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDeadlineIndex(t *testing.T) {
+	d := NewDeadlineIndex[string]()
+	base := time.Unix(1000, 0)
+	if _, ok := d.NextDeadline(); ok {
+		t.Errorf("NextDeadline() on an empty index should not be ok")
+	}
+	d.Add("c", base.Add(3*time.Second))
+	d.Add("a", base.Add(1*time.Second))
+	d.Add("b", base.Add(1*time.Second))
+	d.Add("d", base.Add(4*time.Second))
+	if !d.Add("d", base.Add(2*time.Second)) {
+		t.Errorf("Add(d) again = false; want true")
+	}
+	if at, ok := d.NextDeadline(); !ok || !at.Equal(base.Add(time.Second)) {
+		t.Errorf("NextDeadline() = %v, %v; want %v, true", at, ok, base.Add(time.Second))
+	}
+	if !d.Cancel("c") || d.Cancel("c") {
+		t.Errorf("Cancel(c) should succeed exactly once")
+	}
+	if got := d.PopExpired(base); len(got) != 0 {
+		t.Errorf("PopExpired(base) = %v; want none", got)
+	}
+	if got := d.PopExpired(base.Add(2 * time.Second)); !slices.Equal(got, []string{"a", "b", "d"}) {
+		t.Errorf("PopExpired(base+2s) = %v; want [a b d]", got)
+	}
+	if d.Len() != 0 || d.Cancel("a") {
+		t.Errorf("expired ids should be gone from the index")
+	}
+}
+
+func TestDeadlineIndexRun(t *testing.T) {
+	d := NewDeadlineIndex[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	expired := make(chan int, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.Run(ctx, func(ids []int) {
+			for _, id := range ids {
+				expired <- id
+			}
+		})
+	}()
+
+	now := time.Now()
+	d.Add(2, now.Add(40*time.Millisecond))
+	d.Add(3, now.Add(time.Hour))
+	d.Add(1, now.Add(10*time.Millisecond))
+	d.Cancel(3)
+	for _, want := range []int{1, 2} {
+		select {
+		case got := <-expired:
+			if got != want {
+				t.Errorf("expired %d; want %d", got, want)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("id %d did not expire", want)
+		}
+	}
+	cancel()
+	<-done
+}
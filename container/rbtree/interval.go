@@ -0,0 +1,127 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Interval is the closed interval [Start, End], Start must not be greater than End.
+type Interval[T any] struct {
+	Start, End T
+}
+
+// IntervalItem is an interval stored in an IntervalTree with its value.
+type IntervalItem[T, V any] struct {
+	Interval[T]
+	Value V
+}
+
+type ivEntry[T, V any] struct {
+	item IntervalItem[T, V]
+	// maxEnd is the greatest End in the subtree rooted at the node holding the entry.
+	maxEnd T
+}
+
+// IntervalTree stores closed intervals ordered by start and then by end,
+// every node keeps the greatest end of its subtree so overlap queries can
+// skip the subtrees that end too early. The same interval may be stored
+// more than once.
+type IntervalTree[T, V any] struct {
+	tree *RBTree[ivEntry[T, V]]
+	cmp  func(a, b T) int
+}
+
+// NewIntervalTree returns an empty interval tree ordered by cmp.Compare.
+func NewIntervalTree[T cmp.Ordered, V any](opts ...Option) *IntervalTree[T, V] {
+	return NewIntervalTreeFunc[T, V](cmp.Compare[T], opts...)
+}
+
+// NewIntervalTreeFunc returns an empty interval tree whose bounds are ordered by cmp.
+func NewIntervalTreeFunc[T, V any](cmp func(a, b T) int, opts ...Option) *IntervalTree[T, V] {
+	t := &IntervalTree[T, V]{cmp: cmp}
+	t.tree = NewFunc(func(a, b ivEntry[T, V]) int {
+		if c := cmp(a.item.Start, b.item.Start); c != 0 {
+			return c
+		}
+		return cmp(a.item.End, b.item.End)
+	}, opts...)
+	t.tree.augment = t.pull
+	return t
+}
+
+// pull recomputes the greatest end below n.
+func (t *IntervalTree[T, V]) pull(n *RBNode[ivEntry[T, V]]) {
+	n.key.maxEnd = n.key.item.End
+	for _, c := range n.child {
+		if c != nil && t.cmp(c.key.maxEnd, n.key.maxEnd) > 0 {
+			n.key.maxEnd = c.key.maxEnd
+		}
+	}
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[T, V]) Len() int {
+	return t.tree.Len()
+}
+
+// Insert adds iv with its value to the tree.
+func (t *IntervalTree[T, V]) Insert(iv Interval[T], value V) {
+	t.tree.Insert(ivEntry[T, V]{item: IntervalItem[T, V]{iv, value}})
+}
+
+// Delete removes one interval with the same bounds as iv
+// and reports whether one was found.
+func (t *IntervalTree[T, V]) Delete(iv Interval[T]) bool {
+	return t.tree.EraseKey(ivEntry[T, V]{item: IntervalItem[T, V]{Interval: iv}})
+}
+
+// All returns an iterator over every interval ordered by start and then by end.
+func (t *IntervalTree[T, V]) All() iter.Seq[IntervalItem[T, V]] {
+	return func(yield func(IntervalItem[T, V]) bool) {
+		for e := range t.tree.All() {
+			if !yield(e.item) {
+				return
+			}
+		}
+	}
+}
+
+// OverlappingSeq returns an iterator over the intervals that share at least
+// one point with [lo, hi], ordered by start and then by end.
+func (t *IntervalTree[T, V]) OverlappingSeq(lo, hi T) iter.Seq[IntervalItem[T, V]] {
+	return func(yield func(IntervalItem[T, V]) bool) {
+		var visit func(n *RBNode[ivEntry[T, V]]) bool
+		visit = func(n *RBNode[ivEntry[T, V]]) bool {
+			// nothing below n reaches lo
+			if n == nil || t.cmp(n.key.maxEnd, lo) < 0 {
+				return true
+			}
+			if !visit(n.getChild(DIR_LEFT)) {
+				return false
+			}
+			// n and everything on its right start after hi
+			if t.cmp(n.key.item.Start, hi) > 0 {
+				return true
+			}
+			if t.cmp(n.key.item.End, lo) >= 0 && !yield(n.key.item) {
+				return false
+			}
+			return visit(n.getChild(DIR_RIGHT))
+		}
+		visit(t.tree.root)
+	}
+}
+
+// Overlapping returns the intervals that share at least one point with [lo, hi].
+func (t *IntervalTree[T, V]) Overlapping(lo, hi T) []IntervalItem[T, V] {
+	var res []IntervalItem[T, V]
+	for item := range t.OverlappingSeq(lo, hi) {
+		res = append(res, item)
+	}
+	return res
+}
+
+// Stabbing returns the intervals that contain point.
+func (t *IntervalTree[T, V]) Stabbing(point T) []IntervalItem[T, V] {
+	return t.Overlapping(point, point)
+}
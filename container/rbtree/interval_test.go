@@ -0,0 +1,85 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIntervalTree(t *testing.T) {
+	tree := NewIntervalTree[int, string]()
+	tree.Insert(Interval[int]{1, 5}, "a")
+	tree.Insert(Interval[int]{3, 8}, "b")
+	tree.Insert(Interval[int]{10, 12}, "c")
+	tree.Insert(Interval[int]{6, 6}, "d")
+	values := func(items []IntervalItem[int, string]) (res []string) {
+		for _, it := range items {
+			res = append(res, it.Value)
+		}
+		return
+	}
+	tests := []struct {
+		name string
+		got  []IntervalItem[int, string]
+		want []string
+	}{
+		{"Stabbing(4)", tree.Stabbing(4), []string{"a", "b"}},
+		{"Stabbing(6)", tree.Stabbing(6), []string{"b", "d"}},
+		{"Stabbing(9)", tree.Stabbing(9), nil},
+		{"Stabbing(12)", tree.Stabbing(12), []string{"c"}},
+		{"Overlapping(5, 10)", tree.Overlapping(5, 10), []string{"a", "b", "d", "c"}},
+		{"Overlapping(13, 20)", tree.Overlapping(13, 20), nil},
+	}
+	for _, tt := range tests {
+		if got := values(tt.got); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+	if !tree.Delete(Interval[int]{3, 8}) || tree.Delete(Interval[int]{3, 8}) {
+		t.Errorf("Delete([3, 8]) should succeed exactly once")
+	}
+	if got := values(tree.Stabbing(7)); got != nil {
+		t.Errorf("Stabbing(7) after Delete = %v; want none", got)
+	}
+	for item := range tree.OverlappingSeq(0, 100) {
+		if item.Value != "a" {
+			t.Errorf("OverlappingSeq should yield a first, got %s", item.Value)
+		}
+		break
+	}
+}
+
+func TestIntervalTreeRandom(t *testing.T) {
+	tree := NewIntervalTree[int, int]()
+	var ref []Interval[int]
+	for i := 0; i < 1000; i++ {
+		if len(ref) > 0 && rand.Intn(3) == 0 {
+			at := rand.Intn(len(ref))
+			if !tree.Delete(ref[at]) {
+				t.Fatalf("Delete(%v) = false; want true", ref[at])
+			}
+			ref = slices.Delete(ref, at, at+1)
+			continue
+		}
+		s := rand.Intn(1000)
+		iv := Interval[int]{s, s + rand.Intn(50)}
+		tree.Insert(iv, i)
+		ref = append(ref, iv)
+	}
+	if err := tree.tree.Validate(); err != nil {
+		t.Fatalf("Validate(): %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		lo := rand.Intn(1100)
+		hi := lo + rand.Intn(30)
+		want := 0
+		for _, iv := range ref {
+			if iv.Start <= hi && iv.End >= lo {
+				want++
+			}
+		}
+		if got := len(tree.Overlapping(lo, hi)); got != want {
+			t.Fatalf("Overlapping(%d, %d) found %d intervals; want %d", lo, hi, got, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package rbtree
+
+import "iter"
+
+// The iterators below walk the tree with the parent links instead of
+// recursion, so a loop over them can stop early with break.
+// The tree must not be modified while an iterator is running.
+
+func (t *RBTree[T]) walk(n *RBNode[T], dir bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for ; n != nil; n = t.neighbour(n, dir) {
+			if !yield(n.key) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys in ascending order.
+func (t *RBTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.walk(t.Min(), DIR_RIGHT)(yield)
+	}
+}
+
+// Backward returns an iterator over the keys in descending order.
+func (t *RBTree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.walk(t.Max(), DIR_LEFT)(yield)
+	}
+}
+
+// Ascend returns an iterator over the keys greater than or equal to from,
+// in ascending order.
+func (t *RBTree[T]) Ascend(from T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.walk(t.Ceiling(from), DIR_RIGHT)(yield)
+	}
+}
+
+// Descend returns an iterator over the keys less than or equal to from,
+// in descending order.
+func (t *RBTree[T]) Descend(from T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		t.walk(t.Floor(from), DIR_LEFT)(yield)
+	}
+}
@@ -0,0 +1,67 @@
+package rbtree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIterators(t *testing.T) {
+	tree := New[int]()
+	if got := slices.Collect(tree.All()); len(got) != 0 {
+		t.Errorf("All() on an empty tree = %v; want empty", got)
+	}
+	for _, k := range []int{5, 1, 4, 2, 3} {
+		tree.Insert(k)
+	}
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"All()", slices.Collect(tree.All()), []int{1, 2, 3, 4, 5}},
+		{"Backward()", slices.Collect(tree.Backward()), []int{5, 4, 3, 2, 1}},
+		{"Ascend(3)", slices.Collect(tree.Ascend(3)), []int{3, 4, 5}},
+		{"Ascend(6)", slices.Collect(tree.Ascend(6)), nil},
+		{"Descend(3)", slices.Collect(tree.Descend(3)), []int{3, 2, 1}},
+		{"Descend(0)", slices.Collect(tree.Descend(0)), nil},
+	}
+	for _, tt := range tests {
+		if !slices.Equal(tt.got, tt.want) {
+			t.Errorf("%s = %v; want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	var got []int
+	for k := range tree.All() {
+		if k == 3 {
+			break
+		}
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("break in All() collected %v; want [1 2]", got)
+	}
+}
+
+func TestTreeMapIterators(t *testing.T) {
+	m := NewTreeMap[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+	m.Put("c", 3)
+	var keys []string
+	var sum int
+	for k, v := range m.All() {
+		keys = append(keys, k)
+		sum += v
+	}
+	if !slices.Equal(keys, []string{"a", "b", "c"}) || sum != 6 {
+		t.Errorf("All() = %v, sum %d; want [a b c], sum 6", keys, sum)
+	}
+	keys = keys[:0]
+	for k := range m.Descend("b") {
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []string{"b", "a"}) {
+		t.Errorf("Descend(b) = %v; want [b a]", keys)
+	}
+}
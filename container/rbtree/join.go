@@ -0,0 +1,298 @@
+package rbtree
+
+// The functions below implement the join-based algorithms from
+// "Just Join for Parallel Ordered Sets" (Blelloch, Ferizovic and Sun).
+// They work on detached subtrees whose root may be red, every node they
+// produce goes through joiner.link, so the same code serves the mutable
+// RBTree, which relinks nodes in place, and the PersistentTree, which
+// copies them. Parent links of the returned root are not meaningful.
+// Black heights are passed down the recursion rather than recomputed, so
+// a join costs O(1) plus the difference of the black heights it joins.
+// Split and Join then run in O(log n), and Union, Intersect and Difference
+// of trees of sizes m <= n in O(m log(n/m + 1)).
+
+type joiner[T any] struct {
+	cmp func(a, b T) int
+	// link makes l and r the children of k and returns the subtree root.
+	link func(l, k, r *RBNode[T], color Color) *RBNode[T]
+}
+
+// blackHeight returns the number of black nodes on a path from n to nil.
+func blackHeight[T any](n *RBNode[T]) (h int) {
+	for ; n != nil; n = n.getChild(DIR_LEFT) {
+		if !isRed(n) {
+			h++
+		}
+	}
+	return
+}
+
+// subtree is a detached subtree together with its black height. The join
+// core threads black heights through its recursion, deriving those of the
+// children from their parent in O(1), so a join never walks a tree to find
+// one. blackHeight is only called where a subtree enters the core.
+type subtree[T any] struct {
+	root *RBNode[T]
+	bh   int
+}
+
+func newSubtree[T any](n *RBNode[T]) subtree[T] {
+	return subtree[T]{n, blackHeight(n)}
+}
+
+// child returns the dir subtree of s, which must not be empty.
+func (s subtree[T]) child(dir bool) subtree[T] {
+	return subtree[T]{s.root.getChild(dir), s.bh - btoi(!isRed(s.root))}
+}
+
+// link makes l and r the children of k in place and returns k.
+func link[T any](l, k, r *RBNode[T], color Color) *RBNode[T] {
+	k.child = [2]*RBNode[T]{l, r}
+	k.color = color
+	k.size = getSize(l) + getSize(r) + 1
+	if l != nil {
+		l.parent = k
+	}
+	if r != nil {
+		r.parent = k
+	}
+	return k
+}
+
+// linkDir is link with near placed on the !dir side and far on the dir side.
+func (j joiner[T]) linkDir(near, k, far *RBNode[T], color Color, dir bool) *RBNode[T] {
+	if dir == DIR_RIGHT {
+		return j.link(near, k, far, color)
+	}
+	return j.link(far, k, near, color)
+}
+
+// recolor returns n painted with color.
+func (j joiner[T]) recolor(n *RBNode[T], color Color) *RBNode[T] {
+	return j.link(n.getChild(DIR_LEFT), n, n.getChild(DIR_RIGHT), color)
+}
+
+// joinSide walks down the dir spine of big until it reaches a black node
+// with the black height of small and hangs k there. The result has the
+// black height of big, its root may be red with a red dir child.
+func (j joiner[T]) joinSide(big subtree[T], k *RBNode[T], small subtree[T], dir bool) *RBNode[T] {
+	if !isRed(big.root) && big.bh == small.bh {
+		return j.linkDir(big.root, k, small.root, RED, dir)
+	}
+	n := big.root
+	other := n.getChild(!dir)
+	sub := j.joinSide(big.child(dir), k, small, dir)
+	// a black big with two red nodes below it on the dir spine
+	// is rotated towards !dir, so sub becomes the subtree root
+	//     big               [sub]
+	//    /   \              /   \
+	//  ...  [sub]   ==>   big    x
+	//       /   \         / \
+	//      y    [x]     ...  y
+	if !isRed(n) && isRed(sub) && isRed(sub.getChild(dir)) {
+		inner, outer := sub.getChild(!dir), j.recolor(sub.getChild(dir), BLACK)
+		return j.linkDir(j.linkDir(other, n, inner, BLACK, dir), sub, outer, RED, dir)
+	}
+	return j.linkDir(other, n, sub, n.color, dir)
+}
+
+// join returns a tree holding the keys of l, then k, then the keys of r.
+func (j joiner[T]) join(l subtree[T], k *RBNode[T], r subtree[T]) subtree[T] {
+	switch {
+	case l.bh > r.bh:
+		res := subtree[T]{j.joinSide(l, k, r, DIR_RIGHT), l.bh}
+		if isRed(res.root) && isRed(res.root.getChild(DIR_RIGHT)) {
+			res.root = j.recolor(res.root, BLACK)
+			res.bh++
+		}
+		return res
+	case l.bh < r.bh:
+		res := subtree[T]{j.joinSide(r, k, l, DIR_LEFT), r.bh}
+		if isRed(res.root) && isRed(res.root.getChild(DIR_LEFT)) {
+			res.root = j.recolor(res.root, BLACK)
+			res.bh++
+		}
+		return res
+	case !isRed(l.root) && !isRed(r.root):
+		return subtree[T]{j.link(l.root, k, r.root, RED), l.bh}
+	default:
+		return subtree[T]{j.link(l.root, k, r.root, BLACK), l.bh + 1}
+	}
+}
+
+// splitFirst removes the smallest node of s and returns the rest and that node.
+func (j joiner[T]) splitFirst(s subtree[T]) (rest subtree[T], first *RBNode[T]) {
+	if s.root.getChild(DIR_LEFT) == nil {
+		return s.child(DIR_RIGHT), s.root
+	}
+	rest, first = j.splitFirst(s.child(DIR_LEFT))
+	return j.join(rest, s.root, s.child(DIR_RIGHT)), first
+}
+
+// join2 concatenates l and r without a middle node.
+func (j joiner[T]) join2(l, r subtree[T]) subtree[T] {
+	if r.root == nil {
+		return l
+	}
+	rest, first := j.splitFirst(r)
+	return j.join(l, first, rest)
+}
+
+// split divides s into the keys less than key and the keys not less than key.
+func (j joiner[T]) split(s subtree[T], key T) (l, r subtree[T]) {
+	if s.root == nil {
+		return s, s
+	}
+	if j.cmp(key, s.root.key) <= 0 {
+		ll, lr := j.split(s.child(DIR_LEFT), key)
+		return ll, j.join(lr, s.root, s.child(DIR_RIGHT))
+	}
+	rl, rr := j.split(s.child(DIR_RIGHT), key)
+	return j.join(s.child(DIR_LEFT), s.root, rl), rr
+}
+
+// splitAt divides s into its first i nodes and the rest.
+func (j joiner[T]) splitAt(s subtree[T], i int) (l, r subtree[T]) {
+	if s.root == nil {
+		return s, s
+	}
+	ls := getSize(s.root.getChild(DIR_LEFT))
+	if i <= ls {
+		ll, lr := j.splitAt(s.child(DIR_LEFT), i)
+		return ll, j.join(lr, s.root, s.child(DIR_RIGHT))
+	}
+	rl, rr := j.splitAt(s.child(DIR_RIGHT), i-ls-1)
+	return j.join(s.child(DIR_LEFT), s.root, rl), rr
+}
+
+// split3 divides s into the keys less than key, one node equal to key
+// if there is any, and the keys greater than key.
+func (j joiner[T]) split3(s subtree[T], key T) (l subtree[T], eq *RBNode[T], r subtree[T]) {
+	if s.root == nil {
+		return s, nil, s
+	}
+	c := j.cmp(key, s.root.key)
+	if c == 0 {
+		return s.child(DIR_LEFT), s.root, s.child(DIR_RIGHT)
+	}
+	if c < 0 {
+		ll, eq, lr := j.split3(s.child(DIR_LEFT), key)
+		return ll, eq, j.join(lr, s.root, s.child(DIR_RIGHT))
+	}
+	rl, eq, rr := j.split3(s.child(DIR_RIGHT), key)
+	return j.join(s.child(DIR_LEFT), s.root, rl), eq, rr
+}
+
+func (j joiner[T]) union(a, b subtree[T]) subtree[T] {
+	if a.root == nil {
+		return b
+	}
+	if b.root == nil {
+		return a
+	}
+	al, eq, ar := j.split3(a, b.root.key)
+	l, r := j.union(al, b.child(DIR_LEFT)), j.union(ar, b.child(DIR_RIGHT))
+	if eq == nil {
+		eq = b.root
+	}
+	return j.join(l, eq, r)
+}
+
+func (j joiner[T]) intersect(a, b subtree[T]) subtree[T] {
+	if a.root == nil || b.root == nil {
+		return subtree[T]{}
+	}
+	al, eq, ar := j.split3(a, b.root.key)
+	l, r := j.intersect(al, b.child(DIR_LEFT)), j.intersect(ar, b.child(DIR_RIGHT))
+	if eq != nil {
+		return j.join(l, eq, r)
+	}
+	return j.join2(l, r)
+}
+
+func (j joiner[T]) difference(a, b subtree[T]) subtree[T] {
+	if a.root == nil || b.root == nil {
+		return a
+	}
+	al, _, ar := j.split3(a, b.root.key)
+	return j.join2(j.difference(al, b.child(DIR_LEFT)), j.difference(ar, b.child(DIR_RIGHT)))
+}
+
+// joiner returns the join core that relinks the nodes of t in place.
+func (t *RBTree[T]) joiner() joiner[T] {
+	if t.augment == nil {
+		return joiner[T]{cmp: t.cmp, link: link[T]}
+	}
+	return joiner[T]{cmp: t.cmp, link: func(l, k, r *RBNode[T], color Color) *RBNode[T] {
+		link(l, k, r, color)
+		t.update(k)
+		return k
+	}}
+}
+
+// setRoot installs n as the root of t.
+func (t *RBTree[T]) setRoot(n *RBNode[T]) {
+	t.root = n
+	if n != nil {
+		n.parent = nil
+		n.color = BLACK
+	}
+}
+
+// Split moves every key not less than key into a new tree and returns it,
+// t keeps the keys less than key. The new tree has the comparator and the
+// options of t.
+func (t *RBTree[T]) Split(key T) *RBTree[T] {
+	l, r := t.joiner().split(newSubtree(t.root), key)
+	t.setRoot(l.root)
+	right := t.empty()
+	right.setRoot(r.root)
+	return right
+}
+
+// Join appends the keys of other to t and leaves other empty.
+// Every key of other must not be less than the keys of t.
+func (t *RBTree[T]) Join(other *RBTree[T]) {
+	t.setRoot(t.joiner().join2(newSubtree(t.root), newSubtree(other.root)).root)
+	other.root = nil
+}
+
+// Split is RBTree.Split for a set, it returns the keys not less than key
+// as a new set.
+func (s *TreeSet[T]) Split(key T) *TreeSet[T] {
+	return &TreeSet[T]{s.RBTree.Split(key)}
+}
+
+// Join appends the keys of other to s, leaves other empty and reports
+// true. Every key of other must be greater than the keys of s, otherwise
+// the sets would share a key, and neither set is modified and false is
+// returned.
+func (s *TreeSet[T]) Join(other *TreeSet[T]) bool {
+	if s.root != nil && other.root != nil && s.cmp(rightmost(s.root).key, leftmost(other.root).key) >= 0 {
+		return false
+	}
+	s.RBTree.Join(other.RBTree)
+	return true
+}
+
+// The set operations below need unique keys, so they are defined on TreeSet
+// only. The result is stored in s and other is left empty. When a key is in
+// both sets the node of s is kept.
+
+// Union adds the keys of other to s.
+func (s *TreeSet[T]) Union(other *TreeSet[T]) {
+	s.setRoot(s.joiner().union(newSubtree(s.root), newSubtree(other.root)).root)
+	other.root = nil
+}
+
+// Intersect keeps the keys of s that are also in other.
+func (s *TreeSet[T]) Intersect(other *TreeSet[T]) {
+	s.setRoot(s.joiner().intersect(newSubtree(s.root), newSubtree(other.root)).root)
+	other.root = nil
+}
+
+// Difference removes the keys of other from s.
+func (s *TreeSet[T]) Difference(other *TreeSet[T]) {
+	s.setRoot(s.joiner().difference(newSubtree(s.root), newSubtree(other.root)).root)
+	other.root = nil
+}
@@ -0,0 +1,160 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func randomSet(n, limit int) (*TreeSet[int], []int) {
+	s := NewTreeSet[int]()
+	for i := 0; i < n; i++ {
+		s.Insert(rand.Intn(limit))
+	}
+	return s, slices.Collect(s.All())
+}
+
+func checkTree(t *testing.T, name string, tree *RBTree[int], want []int) {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("%s: Validate(): %v", name, err)
+	}
+	if got := slices.Collect(tree.All()); !slices.Equal(got, want) {
+		t.Fatalf("%s = %v; want %v", name, got, want)
+	}
+	if tree.Len() != len(want) {
+		t.Fatalf("%s: Len() = %d; want %d", name, tree.Len(), len(want))
+	}
+}
+
+func TestSplitAndJoin(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		s, all := randomSet(rand.Intn(200), 300)
+		key := rand.Intn(300)
+		right := s.Split(key)
+		at, _ := slices.BinarySearch(all, key)
+		checkTree(t, "left of Split", s.RBTree, all[:at])
+		checkTree(t, "right of Split", right.RBTree, all[at:])
+
+		if !s.Join(right) {
+			t.Fatalf("Join() = false for disjoint sets")
+		}
+		checkTree(t, "Join", s.RBTree, all)
+		if right.Len() != 0 {
+			t.Fatalf("Join left %d keys in its argument", right.Len())
+		}
+	}
+}
+
+func TestTreeSetJoinOverlap(t *testing.T) {
+	a, b := NewTreeSet[int](), NewTreeSet[int]()
+	for _, k := range []int{1, 2, 3} {
+		a.Insert(k)
+		b.Insert(k + 2)
+	}
+	if a.Join(b) {
+		t.Errorf("Join() = true for sets sharing key 3")
+	}
+	checkTree(t, "refused Join", a.RBTree, []int{1, 2, 3})
+	checkTree(t, "argument of refused Join", b.RBTree, []int{3, 4, 5})
+
+	b.EraseKey(3)
+	if !a.Join(b) {
+		t.Errorf("Join() = false for disjoint sets")
+	}
+	checkTree(t, "Join", a.RBTree, []int{1, 2, 3, 4, 5})
+	if !a.Join(NewTreeSet[int]()) || !NewTreeSet[int]().Join(a) {
+		t.Errorf("Join() = false with an empty set")
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		a, as := randomSet(rand.Intn(200), 300)
+		b, bs := randomSet(rand.Intn(200), 300)
+		var union, inter, diff []int
+		for k := 0; k < 300; k++ {
+			_, inA := slices.BinarySearch(as, k)
+			_, inB := slices.BinarySearch(bs, k)
+			if inA || inB {
+				union = append(union, k)
+			}
+			if inA && inB {
+				inter = append(inter, k)
+			}
+			if inA && !inB {
+				diff = append(diff, k)
+			}
+		}
+		clone := func(keys []int) *TreeSet[int] {
+			tree := NewTreeSet[int]()
+			for _, k := range keys {
+				tree.Insert(k)
+			}
+			return tree
+		}
+
+		u := clone(as)
+		u.Union(clone(bs))
+		checkTree(t, "Union", u.RBTree, union)
+
+		in := clone(as)
+		in.Intersect(clone(bs))
+		checkTree(t, "Intersect", in.RBTree, inter)
+
+		a.Difference(b)
+		checkTree(t, "Difference", a.RBTree, diff)
+		if b.Len() != 0 {
+			t.Fatalf("Difference left %d keys in its argument", b.Len())
+		}
+	}
+}
+
+func BenchmarkUnion(b *testing.B) {
+	const n = 100000
+	build := func(start int) *TreeSet[int] {
+		tree := NewTreeSet[int]()
+		for i := 0; i < n; i++ {
+			tree.Insert(start + i*2)
+		}
+		return tree
+	}
+	b.Run("Union", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			x, y := build(0), build(1)
+			b.StartTimer()
+			x.Union(y)
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			x, y := build(0), build(1)
+			b.StartTimer()
+			for k := range y.All() {
+				x.Insert(k)
+			}
+		}
+	})
+}
+
+func TestSplitKeepsOptions(t *testing.T) {
+	tree := New[int](WithArena(8))
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+	right := tree.Split(50)
+	if right.pool == nil || right.pool.slabSize != 8 {
+		t.Fatalf("Split() dropped the arena option")
+	}
+	if right.pool == tree.pool {
+		t.Fatalf("Split() shares the node pool between both trees")
+	}
+	right.EraseKey(60)
+	right.Insert(60)
+	checkTree(t, "right of Split", right, slices.Collect(func(yield func(int) bool) {
+		for i := 50; i < 100 && yield(i); i++ {
+		}
+	}))
+}
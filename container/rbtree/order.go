@@ -0,0 +1,65 @@
+package rbtree
+
+// Every node keeps the size of its subtree, which lets the tree answer
+// order-statistic queries in O(log n).
+
+// rank returns the number of keys less than key, or less than or equal to
+// key if inclusive is set.
+func (t *RBTree[T]) rank(key T, inclusive bool) (ans int) {
+	now := t.root
+	for now != nil {
+		c := t.cmp(now.key, key)
+		if c < 0 || c == 0 && inclusive {
+			ans += getSize(now.getChild(DIR_LEFT)) + 1
+			now = now.getChild(DIR_RIGHT)
+		} else {
+			now = now.getChild(DIR_LEFT)
+		}
+	}
+	return
+}
+
+// Rank returns the number of keys less than key.
+func (t *RBTree[T]) Rank(key T) int {
+	return t.rank(key, false)
+}
+
+// Select returns the node holding the k-th smallest key, counting from 0,
+// or nil if k is out of range.
+func (t *RBTree[T]) Select(k int) *RBNode[T] {
+	now := t.root
+	for now != nil {
+		lsize := getSize(now.getChild(DIR_LEFT))
+		if k < lsize {
+			now = now.getChild(DIR_LEFT)
+		} else if k == lsize {
+			return now
+		} else {
+			now = now.getChild(DIR_RIGHT)
+			k -= lsize + 1
+		}
+	}
+	return nil
+}
+
+// Order returns the number of keys less than key.
+//
+// Deprecated: use Rank.
+func (t *RBTree[T]) Order(key T) int {
+	return t.Rank(key)
+}
+
+// FindOrder returns the node holding the order-th smallest key, counting from 0,
+// or nil if order is out of range.
+//
+// Deprecated: use Select.
+func (t *RBTree[T]) FindOrder(order int) *RBNode[T] {
+	return t.Select(order)
+}
+
+// CountRange returns the number of keys between lo and hi,
+// see Range for the meaning of mode.
+func (t *RBTree[T]) CountRange(lo, hi T, mode RangeMode) int {
+	n := t.rank(hi, !mode.hiOpen()) - t.rank(lo, mode.loOpen())
+	return max(n, 0)
+}
@@ -0,0 +1,98 @@
+package rbtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRankAndSelect(t *testing.T) {
+	tree := New[int]()
+	for _, k := range rand.Perm(100) {
+		tree.Insert(k * 2)
+	}
+	for i := 0; i < 100; i++ {
+		if got := tree.Rank(i * 2); got != i {
+			t.Errorf("Rank(%d) = %d; want %d", i*2, got, i)
+		}
+		if got := tree.Rank(i*2 + 1); got != i+1 {
+			t.Errorf("Rank(%d) = %d; want %d", i*2+1, got, i+1)
+		}
+		if got := tree.Select(i); got == nil || got.Key() != i*2 {
+			t.Errorf("Select(%d) = %v; want %d", i, got, i*2)
+		}
+	}
+	if got := tree.Select(100); got != nil {
+		t.Errorf("Select(100) = %d; want nil", got.Key())
+	}
+	if got := tree.Select(-1); got != nil {
+		t.Errorf("Select(-1) = %d; want nil", got.Key())
+	}
+
+	for _, k := range rand.Perm(100)[:50] {
+		tree.EraseKey(k * 2)
+	}
+	for i := 0; i < tree.Len(); i++ {
+		if got := tree.Rank(tree.Select(i).Key()); got != i {
+			t.Errorf("Rank(Select(%d)) = %d after erase", i, got)
+		}
+	}
+}
+
+func TestRankDuplicates(t *testing.T) {
+	tree := New[int]()
+	for _, k := range []int{1, 2, 2, 2, 3} {
+		tree.Insert(k)
+	}
+	if got := tree.Rank(2); got != 1 {
+		t.Errorf("Rank(2) = %d; want 1", got)
+	}
+	if got := tree.Rank(3); got != 4 {
+		t.Errorf("Rank(3) = %d; want 4", got)
+	}
+	if got := tree.CountRange(2, 2, RANGE_CLOSED); got != 3 {
+		t.Errorf("CountRange(2, 2) = %d; want 3", got)
+	}
+}
+
+func TestCountRange(t *testing.T) {
+	tree := New[int]()
+	for i := 1; i <= 10; i++ {
+		tree.Insert(i)
+	}
+	tests := []struct {
+		lo, hi int
+		mode   RangeMode
+		want   int
+	}{
+		{3, 6, RANGE_CLOSED, 4},
+		{3, 6, RANGE_OPEN, 2},
+		{3, 6, RANGE_LEFT_OPEN, 3},
+		{3, 6, RANGE_RIGHT_OPEN, 3},
+		{0, 100, RANGE_CLOSED, 10},
+		{6, 3, RANGE_CLOSED, 0},
+		{5, 5, RANGE_OPEN, 0},
+	}
+	for _, tt := range tests {
+		if got := tree.CountRange(tt.lo, tt.hi, tt.mode); got != tt.want {
+			t.Errorf("CountRange(%d, %d, %d) = %d; want %d", tt.lo, tt.hi, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	tree := New[int]()
+	for _, k := range rand.Perm(100) {
+		tree.Insert(k * 2)
+	}
+	for i := 0; i < 100; i++ {
+		if got := tree.Order(i * 2); got != i {
+			t.Errorf("Order(%d) = %d; want %d", i*2, got, i)
+		}
+		if got := tree.FindOrder(i); got == nil || got.Key() != i*2 {
+			t.Errorf("FindOrder(%d) = %v; want %d", i, got, i*2)
+		}
+	}
+	if got := tree.FindOrder(100); got != nil {
+		t.Errorf("FindOrder(100) = %v; want nil", got.Key())
+	}
+}
@@ -0,0 +1,180 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// PersistentTree is an immutable red-black tree. Insert and Delete return
+// a new version in O(log n) time. They copy the nodes on the path to the
+// key and O(1) more per level for rebalancing, every other subtree is
+// shared with the older versions. A version never changes once created, so
+// it can be read by any number of goroutines while newer versions are
+// being built.
+//
+// Updates are built on the join core in join.go, with nodes copied instead
+// of relinked and black heights passed down the recursion, so rebalancing
+// each level is a join of subtrees whose black heights differ by at most
+// one. Shared nodes have no meaningful parent link, so the tree is walked
+// from the root only.
+type PersistentTree[T any] struct {
+	tree RBTree[T]
+}
+
+// NewPersistent returns an empty persistent tree ordered by cmp.Compare.
+func NewPersistent[T cmp.Ordered]() *PersistentTree[T] {
+	return NewPersistentFunc(cmp.Compare[T])
+}
+
+// NewPersistentFunc returns an empty persistent tree ordered by cmp.
+func NewPersistentFunc[T any](cmp func(a, b T) int) *PersistentTree[T] {
+	return &PersistentTree[T]{RBTree[T]{cmp: cmp}}
+}
+
+// copyLink is the link of the persistent join core,
+// it returns a fresh copy of k instead of touching k and its children.
+func copyLink[T any](l, k, r *RBNode[T], color Color) *RBNode[T] {
+	return &RBNode[T]{
+		key:   k.key,
+		color: color,
+		child: [2]*RBNode[T]{l, r},
+		size:  getSize(l) + getSize(r) + 1,
+	}
+}
+
+func (t *PersistentTree[T]) joiner() joiner[T] {
+	return joiner[T]{cmp: t.tree.cmp, link: copyLink[T]}
+}
+
+func (t *PersistentTree[T]) version(root *RBNode[T]) *PersistentTree[T] {
+	if isRed(root) {
+		root = t.joiner().recolor(root, BLACK)
+	}
+	return &PersistentTree[T]{RBTree[T]{root: root, cmp: t.tree.cmp}}
+}
+
+// insert returns a copy of s holding key as well. The black height of a
+// subtree changes by at most one when a key is added below it, so every
+// join on the way up is O(1) and the insert O(log n).
+func (t *PersistentTree[T]) insert(j joiner[T], s subtree[T], key T) subtree[T] {
+	if s.root == nil {
+		return subtree[T]{&RBNode[T]{key: key, color: RED, size: 1}, 0}
+	}
+	if j.cmp(key, s.root.key) < 0 {
+		return j.join(t.insert(j, s.child(DIR_LEFT), key), s.root, s.child(DIR_RIGHT))
+	}
+	return j.join(s.child(DIR_LEFT), s.root, t.insert(j, s.child(DIR_RIGHT), key))
+}
+
+// delete returns a copy of s without the first key equal to key. Like
+// insert it joins subtrees whose black heights differ by at most one,
+// apart from the single join2 that closes the gap left by the key, so it
+// runs in O(log n) as well.
+func (t *PersistentTree[T]) delete(j joiner[T], s subtree[T], key T) (subtree[T], bool) {
+	if s.root == nil {
+		return s, false
+	}
+	l, r := s.child(DIR_LEFT), s.child(DIR_RIGHT)
+	c := j.cmp(key, s.root.key)
+	if c <= 0 {
+		if nl, ok := t.delete(j, l, key); ok {
+			return j.join(nl, s.root, r), true
+		}
+		if c == 0 {
+			return j.join2(l, r), true
+		}
+		return s, false
+	}
+	if nr, ok := t.delete(j, r, key); ok {
+		return j.join(l, s.root, nr), true
+	}
+	return s, false
+}
+
+// Insert returns a new version holding key as well.
+// A key equal to existing ones is placed after them.
+func (t *PersistentTree[T]) Insert(key T) *PersistentTree[T] {
+	return t.version(t.insert(t.joiner(), newSubtree(t.tree.root), key).root)
+}
+
+// Delete returns a new version without the first key equal to key and
+// reports whether one was found. If not, t itself is returned.
+func (t *PersistentTree[T]) Delete(key T) (*PersistentTree[T], bool) {
+	s, ok := t.delete(t.joiner(), newSubtree(t.tree.root), key)
+	if !ok {
+		return t, false
+	}
+	return t.version(s.root), true
+}
+
+// Snapshot returns a handle on this version in O(1).
+// Versions are immutable, so the snapshot never observes later updates.
+func (t *PersistentTree[T]) Snapshot() *PersistentTree[T] {
+	return &PersistentTree[T]{t.tree}
+}
+
+// Len returns the number of keys in the tree.
+func (t *PersistentTree[T]) Len() int {
+	return t.tree.Len()
+}
+
+// Contains reports whether key is in the tree.
+func (t *PersistentTree[T]) Contains(key T) bool {
+	return t.tree.Contains(key)
+}
+
+// Get returns the first key equal to key and whether there is one.
+func (t *PersistentTree[T]) Get(key T) (T, bool) {
+	return keyOf(t.tree.Find(key))
+}
+
+// Min returns the smallest key and whether the tree is not empty.
+func (t *PersistentTree[T]) Min() (T, bool) {
+	return keyOf(t.tree.Min())
+}
+
+// Max returns the largest key and whether the tree is not empty.
+func (t *PersistentTree[T]) Max() (T, bool) {
+	return keyOf(t.tree.Max())
+}
+
+// Rank returns the number of keys less than key.
+func (t *PersistentTree[T]) Rank(key T) int {
+	return t.tree.Rank(key)
+}
+
+// Select returns the k-th smallest key, counting from 0,
+// and whether k is in range.
+func (t *PersistentTree[T]) Select(k int) (T, bool) {
+	return keyOf(t.tree.Select(k))
+}
+
+// All returns an iterator over the keys in ascending order.
+func (t *PersistentTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []*RBNode[T]
+		for n := t.tree.root; n != nil || len(stack) > 0; n = n.getChild(DIR_RIGHT) {
+			for ; n != nil; n = n.getChild(DIR_LEFT) {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.key) {
+				return
+			}
+		}
+	}
+}
+
+// Validate checks the tree like RBTree.Validate, except for parent links.
+func (t *PersistentTree[T]) Validate() error {
+	return t.tree.validate(false)
+}
+
+func keyOf[T any](n *RBNode[T]) (T, bool) {
+	if n == nil {
+		var k T
+		return k, false
+	}
+	return n.key, true
+}
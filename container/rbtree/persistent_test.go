@@ -0,0 +1,95 @@
+package rbtree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestPersistentTree(t *testing.T) {
+	v0 := NewPersistent[int]()
+	versions := []*PersistentTree[int]{v0}
+	models := [][]int{nil}
+	for i := 0; i < 500; i++ {
+		last := versions[len(versions)-1]
+		model := slices.Clone(models[len(models)-1])
+		key := rand.Intn(100)
+		var next *PersistentTree[int]
+		if rand.Intn(3) == 0 {
+			var ok bool
+			next, ok = last.Delete(key)
+			at, found := slices.BinarySearch(model, key)
+			if ok != found {
+				t.Fatalf("Delete(%d) = %v; want %v", key, ok, found)
+			}
+			if found {
+				model = slices.Delete(model, at, at+1)
+			}
+		} else {
+			next = last.Insert(key)
+			at, _ := slices.BinarySearch(model, key+1)
+			model = slices.Insert(model, at, key)
+		}
+		if err := next.Validate(); err != nil {
+			t.Fatalf("Validate() after step %d: %v", i, err)
+		}
+		versions = append(versions, next)
+		models = append(models, model)
+	}
+	for i, v := range versions {
+		if got := slices.Collect(v.All()); !slices.Equal(got, models[i]) {
+			t.Fatalf("version %d = %v; want %v", i, got, models[i])
+		}
+		if v.Len() != len(models[i]) {
+			t.Fatalf("version %d: Len() = %d; want %d", i, v.Len(), len(models[i]))
+		}
+	}
+}
+
+func TestPersistentQueries(t *testing.T) {
+	v := NewPersistent[int]()
+	for _, k := range []int{5, 3, 8, 1} {
+		v = v.Insert(k)
+	}
+	snap := v.Snapshot()
+	v, _ = v.Delete(3)
+	if !snap.Contains(3) || v.Contains(3) {
+		t.Errorf("snapshot should keep 3 while the new version drops it")
+	}
+	if k, ok := v.Min(); !ok || k != 1 {
+		t.Errorf("Min() = %d, %v; want 1, true", k, ok)
+	}
+	if k, ok := v.Max(); !ok || k != 8 {
+		t.Errorf("Max() = %d, %v; want 8, true", k, ok)
+	}
+	if got := v.Rank(8); got != 2 {
+		t.Errorf("Rank(8) = %d; want 2", got)
+	}
+	if k, ok := v.Select(1); !ok || k != 5 {
+		t.Errorf("Select(1) = %d, %v; want 5, true", k, ok)
+	}
+	if _, ok := v.Get(4); ok {
+		t.Errorf("Get(4) found a key; want none")
+	}
+	if same, ok := v.Delete(4); ok || same != v {
+		t.Errorf("Delete(4) should return the receiver unchanged")
+	}
+}
+
+// BenchmarkPersistentInsert should grow with log n, not log² n.
+func BenchmarkPersistentInsert(b *testing.B) {
+	for _, n := range []int{1 << 10, 1 << 16} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			t := NewPersistent[int]()
+			for i := 0; i < n; i++ {
+				t = t.Insert(i * 2)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				t.Insert(i%n*2 + 1).Delete(i % n * 2)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package rbtree
+
+// Option configures the tree behind New, NewFunc, FromSorted, NewTreeSet,
+// NewTreeMultiSet, NewTreeMap, NewSequence, NewAugmentedMap,
+// NewIntervalTree and NewDeadlineIndex.
+type Option func(*options)
+
+type options struct {
+	pool     bool
+	slabSize int
+}
+
+// WithNodePool makes the tree keep the nodes of erased keys and reuse them
+// for later inserts, so a tree whose size stays about the same stops
+// allocating. A node handed out by the tree, through Insert, Find or a
+// Cursor, must not be used once its key has been erased.
+func WithNodePool() Option {
+	return func(o *options) {
+		o.pool = true
+	}
+}
+
+// WithArena is WithNodePool with new nodes carved out of slabs of slabSize
+// nodes, which turns slabSize allocations into one. A slab stays alive as
+// long as any of its nodes does, and the tree never gives slabs back.
+func WithArena(slabSize int) Option {
+	return func(o *options) {
+		o.pool = true
+		o.slabSize = slabSize
+	}
+}
+
+// setOptions applies opts to the empty tree t.
+func (t *RBTree[T]) setOptions(opts []Option) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pool {
+		t.pool = &nodePool[T]{slabSize: o.slabSize}
+	}
+}
+
+// nodePool is a free list of erased nodes, chained through their parent link.
+type nodePool[T any] struct {
+	free     *RBNode[T]
+	slab     []RBNode[T]
+	slabSize int
+}
+
+func (p *nodePool[T]) get() *RBNode[T] {
+	if n := p.free; n != nil {
+		p.free, n.parent = n.parent, nil
+		return n
+	}
+	if p.slabSize <= 1 {
+		return newRBNode[T]()
+	}
+	if len(p.slab) == 0 {
+		p.slab = make([]RBNode[T], p.slabSize)
+	}
+	n := &p.slab[0]
+	p.slab = p.slab[1:]
+	return n
+}
+
+// put adds the detached node n to the free list. The key is cleared so the
+// pool does not keep what it referenced alive.
+func (p *nodePool[T]) put(n *RBNode[T]) {
+	var zero T
+	n.key = zero
+	n.parent, p.free = p.free, n
+}
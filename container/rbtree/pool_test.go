@@ -0,0 +1,138 @@
+package rbtree
+
+import (
+	"cmp"
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestNodePool(t *testing.T) {
+	for _, opt := range []Option{WithNodePool(), WithArena(64)} {
+		tree := New[int](opt)
+		var model []int
+		for i := 0; i < 5000; i++ {
+			k := rand.Intn(300)
+			if rand.Intn(2) == 0 {
+				if tree.EraseKey(k) {
+					at, _ := slices.BinarySearch(model, k)
+					model = slices.Delete(model, at, at+1)
+				}
+			} else {
+				tree.Insert(k)
+				at, _ := slices.BinarySearch(model, k+1)
+				model = slices.Insert(model, at, k)
+			}
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("Validate(): %v", err)
+		}
+		if got := tree.ToSlice(); !slices.Equal(got, model) {
+			t.Fatalf("keys = %v; want %v", got, model)
+		}
+	}
+}
+
+func TestNodePoolReuse(t *testing.T) {
+	tree := New[int](WithNodePool())
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		tree.EraseKey(50)
+		tree.Insert(50)
+	})
+	if allocs != 0 {
+		t.Errorf("erase and insert allocate %v times with a node pool; want 0", allocs)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	arena := WithArena(100)
+	trees := map[string]*RBTree[int]{
+		"NewTreeSet":          NewTreeSet[int](arena).RBTree,
+		"NewTreeSetFunc":      NewTreeSetFunc(cmp.Compare[int], arena).RBTree,
+		"NewTreeMultiSet":     NewTreeMultiSet[int](arena).RBTree,
+		"NewTreeMultiSetFunc": NewTreeMultiSetFunc(cmp.Compare[int], arena).RBTree,
+		"NewSequence":         &NewSequence[int](nil, arena).tree,
+	}
+	for name, tree := range trees {
+		if tree.pool == nil || tree.pool.slabSize != 100 {
+			t.Errorf("%s(WithArena(100)) did not set up the pool", name)
+		}
+	}
+	pools := map[string]int{
+		"NewTreeMap":       NewTreeMap[int, string](arena).tree.pool.slabSize,
+		"NewAugmentedMap":  NewAugmentedMap(SumMonoid[int](), func(k, v int) int { return v }, arena).tree.pool.slabSize,
+		"NewIntervalTree":  NewIntervalTree[int, string](arena).tree.pool.slabSize,
+		"NewDeadlineIndex": NewDeadlineIndex[int](arena).tree.pool.slabSize,
+	}
+	for name, slabSize := range pools {
+		if slabSize != 100 {
+			t.Errorf("%s(WithArena(100)) did not set up the pool", name)
+		}
+	}
+	if NewDeadlineIndex[int]().tree.pool == nil {
+		t.Errorf("NewDeadlineIndex() has no node pool by default")
+	}
+
+	// the builders must take their nodes from the arena, so 64 keys leave
+	// 36 nodes of the first slab
+	keys := make([]int, 64)
+	for i := range keys {
+		keys[i] = i
+	}
+	decoded := New[int](arena)
+	data, _ := json.Marshal(keys)
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatalf("UnmarshalJSON(): %v", err)
+	}
+	built := map[string]*RBTree[int]{
+		"FromSorted":    FromSorted(keys, false, arena),
+		"NewSequence":   &NewSequence(keys, arena).tree,
+		"UnmarshalJSON": decoded,
+	}
+	for name, tree := range built {
+		if len(tree.pool.slab) != 36 {
+			t.Errorf("%s took %d nodes from the arena; want 64", name, 100-len(tree.pool.slab))
+		}
+	}
+}
+
+func benchmarkChurn(b *testing.B, opts ...Option) {
+	const n = 1 << 16
+	tree := New[int](opts...)
+	for i := 0; i < n; i++ {
+		tree.Insert(i)
+	}
+	r := rand.New(rand.NewSource(1))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := r.Intn(n)
+		tree.EraseKey(k)
+		tree.Insert(k)
+	}
+}
+
+func BenchmarkChurn(b *testing.B) {
+	b.Run("Heap", func(b *testing.B) { benchmarkChurn(b) })
+	b.Run("NodePool", func(b *testing.B) { benchmarkChurn(b, WithNodePool()) })
+	b.Run("Arena", func(b *testing.B) { benchmarkChurn(b, WithArena(256)) })
+}
+
+func BenchmarkBuild(b *testing.B) {
+	const n = 1 << 16
+	build := func(b *testing.B, opts ...Option) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			tree := New[int](opts...)
+			for k := 0; k < n; k++ {
+				tree.Insert(k)
+			}
+		}
+	}
+	b.Run("Heap", func(b *testing.B) { build(b) })
+	b.Run("Arena", func(b *testing.B) { build(b, WithArena(256)) })
+}
@@ -8,7 +8,9 @@
 // 4. every path from root to nil node contains the same black nodes
 package rbtree
 
-type RBNode[T comparable] struct {
+import "cmp"
+
+type RBNode[T any] struct {
 	key   T
 	color Color
 
@@ -21,13 +23,18 @@ type RBNode[T comparable] struct {
 	parent *RBNode[T]
 }
 
-func newRBNode[T comparable]() *RBNode[T] {
+func newRBNode[T any]() *RBNode[T] {
 	return &RBNode[T]{
 		child:  [2]*RBNode[T]{nil, nil},
 		parent: nil,
 	}
 }
 
+// Key returns the key stored in the node.
+func (n *RBNode[T]) Key() T {
+	return n.key
+}
+
 func (n *RBNode[T]) getChild(b bool) *RBNode[T] {
 	return n.child[btoi(b)]
 }
@@ -45,8 +52,61 @@ func (n *RBNode[T]) childDir() bool {
 	return n == n.parent.getChild(DIR_RIGHT)
 }
 
-type RBTree[T comparable] struct {
+// RBTree is a red-black tree ordered by a comparison function.
+// Equal keys are kept in insertion order, so the tree behaves as a multiset;
+// use TreeMap when every key should appear at most once.
+// An RBTree must be created with New or NewFunc.
+type RBTree[T any] struct {
 	root *RBNode[T]
+	cmp  func(a, b T) int
+
+	// pool, if set, recycles the nodes of erased keys.
+	pool *nodePool[T]
+
+	// augment, if set, recomputes the subtree aggregate of a node from its
+	// key and its children. It is called bottom-up wherever size changes.
+	augment func(n *RBNode[T])
+}
+
+// New returns an empty tree ordered by cmp.Compare.
+func New[T cmp.Ordered](opts ...Option) *RBTree[T] {
+	return NewFunc(cmp.Compare[T], opts...)
+}
+
+// NewFunc returns an empty tree ordered by cmp, which must return
+// a negative number when a < b, zero when a == b and a positive number when a > b.
+func NewFunc[T any](cmp func(a, b T) int, opts ...Option) *RBTree[T] {
+	t := &RBTree[T]{cmp: cmp}
+	t.setOptions(opts)
+	return t
+}
+
+// empty returns an empty tree with the comparator and the options of t.
+func (t *RBTree[T]) empty() *RBTree[T] {
+	e := &RBTree[T]{cmp: t.cmp, augment: t.augment}
+	if t.pool != nil {
+		e.pool = &nodePool[T]{slabSize: t.pool.slabSize}
+	}
+	return e
+}
+
+// newNode returns a red node holding key, taken from the pool if there is one.
+func (t *RBTree[T]) newNode(key T) *RBNode[T] {
+	var n *RBNode[T]
+	if t.pool != nil {
+		n = t.pool.get()
+	} else {
+		n = newRBNode[T]()
+	}
+	n.key = key
+	n.size = 1
+	n.color = RED
+	return n
+}
+
+// Len returns the number of keys in the tree.
+func (t *RBTree[T]) Len() int {
+	return getSize(t.root)
 }
 
 func (t *RBTree[T]) PreOrder(F func(node *RBNode[T])) {
@@ -88,50 +148,31 @@ func (t *RBTree[T]) PostOrder(F func(node *RBNode[T])) {
 	dfs(t.root)
 }
 
-func (t *RBTree[T]) Order(key T) (ans int) {
-	now := t.root
-	for now != nil {
-		if now.key != key {
-			now = now.getChild(DIR_LEFT)
-		} else {
-			ans += now.getChild(DIR_LEFT).size + now.getChild(DIR_RIGHT).size
-		}
+// update refreshes the subtree aggregate of n, see RBTree.augment.
+func (t *RBTree[T]) update(n *RBNode[T]) {
+	if t.augment != nil {
+		t.augment(n)
 	}
-	return
-}
-
-func (t *RBTree[T]) FindOrder(order int) (ans *RBNode[T]) {
-	now := t.root
-	for now != nil && now.size >= order {
-		lsize := now.getChild(DIR_LEFT).size
-		if order < lsize {
-			now = now.getChild(DIR_LEFT)
-		} else {
-			ans = now
-			if order == lsize {
-				break
-			}
-			now = now.getChild(DIR_RIGHT)
-			order -= lsize + 1
-		}
-	}
-	return
 }
 
+// roate rotates node towards dir, the child on the !dir side becomes
+// the root of the subtree and is returned.
 func (t *RBTree[T]) roate(node *RBNode[T], dir bool) *RBNode[T] {
 	parent := node.parent
-	subtreeRoot := node.getChild(dir)
+	subtreeRoot := node.getChild(!dir)
 	subtreeRoot.size = node.size
-	node.size = node.getChild(dir).size + subtreeRoot.getChild(dir).size + 1
 
 	subtreeDispatchChild := subtreeRoot.getChild(dir)
 	if subtreeDispatchChild != nil {
 		subtreeDispatchChild.parent = node
 	}
 
-	node.setChild(dir, subtreeDispatchChild)
+	node.setChild(!dir, subtreeDispatchChild)
 	subtreeRoot.setChild(dir, node)
 	node.parent = subtreeRoot
+	node.size = getSize(node.getChild(DIR_LEFT)) + getSize(node.getChild(DIR_RIGHT)) + 1
+	t.update(node)
+	t.update(subtreeRoot)
 	subtreeRoot.parent = parent
 	if parent != nil {
 		parent.setChild(node == parent.child[1], subtreeRoot)
@@ -141,81 +182,82 @@ func (t *RBTree[T]) roate(node *RBNode[T], dir bool) *RBNode[T] {
 	return subtreeRoot
 }
 
+// Insert adds data to the tree and returns the new node.
+// A key equal to existing ones is placed after them.
 func (t *RBTree[T]) Insert(data T) *RBNode[T] {
-	n := newRBNode[T]()
-	n.key = data
-	n.size = 1
+	n := t.newNode(data)
 	now := t.root
 	dir := DIR_LEFT
 	var p *RBNode[T]
 	for now != nil {
 		p = now
-		dir = (now.key == data)
+		dir = t.cmp(data, now.key) >= 0
 		now = now.getChild(dir)
 	}
-	insert_fixup_leaf := func() {
-		n.parent = p
-		if p != nil {
-			dir = (data == p.key)
-			p.setChild(dir, n)
-			now = p
-			for now != nil {
-				now.size += 1
-				now = now.parent
-			}
-		} else {
-			t.root = n
-			return
-		}
-		for p = n.parent; isRed(p); p = n.parent {
-			pDir := p.childDir()
-			g := p.parent
-			u := g.getChild(!pDir)
-			// case 1: both p,u are red
-			//      g							[g]
-			//    /   \					 /   \
-			//   [p]  [u] ==>		p    u
-			//  /							 /
-			// [n]						[n]
-			if isRed(u) {
-				p.color = BLACK
-				u.color = BLACK
-				g.color = RED
-				n = g
-				continue
-			}
-			// p is red and u is black
-			// Case 2: dir of n is different with dir of p
-			//    g              g
-			//   / \            / \
-			// [p]  u   ==>   [n]  u
-			//   \            /
-			//   [n]        [p]
-			if n.childDir() != pDir {
-				t.roate(p, pDir)
-				n, p = p, n
-			}
-			// Case 3: p is red, u is black and dir of n is same as dir of p
-			//      g             p
-			//     / \           / \
-			//   [p]  u   ==>  [n] [g]
-			//   /                   \
-			// [n]                    u
+	t.insertAt(n, p, dir)
+	return n
+}
+
+// insertAt links the detached node n as the dir child of p and rebalances the tree.
+func (t *RBTree[T]) insertAt(n, p *RBNode[T], dir bool) {
+	t.update(n)
+	n.parent = p
+	if p == nil {
+		n.color = BLACK
+		t.root = n
+		return
+	}
+	p.setChild(dir, n)
+	for now := p; now != nil; now = now.parent {
+		now.size += 1
+		t.update(now)
+	}
+	for p = n.parent; isRed(p); p = n.parent {
+		pDir := p.childDir()
+		g := p.parent
+		u := g.getChild(!pDir)
+		// case 1: both p,u are red
+		//      g							[g]
+		//    /   \					 /   \
+		//   [p]  [u] ==>		p    u
+		//  /							 /
+		// [n]						[n]
+		if isRed(u) {
 			p.color = BLACK
+			u.color = BLACK
 			g.color = RED
-			t.roate(g, !pDir)
+			n = g
+			continue
+		}
+		// p is red and u is black
+		// Case 2: dir of n is different with dir of p
+		//    g              g
+		//   / \            / \
+		// [p]  u   ==>   [n]  u
+		//   \            /
+		//   [n]        [p]
+		if n.childDir() != pDir {
+			t.roate(p, pDir)
+			n, p = p, n
 		}
-		t.root.color = BLACK
+		// Case 3: p is red, u is black and dir of n is same as dir of p
+		//      g             p
+		//     / \           / \
+		//   [p]  u   ==>  [n] [g]
+		//   /                   \
+		// [n]                    u
+		p.color = BLACK
+		g.color = RED
+		t.roate(g, !pDir)
 	}
-	insert_fixup_leaf()
-
-	return n
+	t.root.color = BLACK
 }
 
+// lowerBound returns the first node whose key is not less than key.
 func (t *RBTree[T]) lowerBound(key T) (ans *RBNode[T]) {
 	now := t.root
 	for now != nil {
-		if now.key != key {
+		if t.cmp(now.key, key) >= 0 {
 			ans = now
 			now = now.getChild(DIR_LEFT)
 		} else {
@@ -225,23 +267,73 @@ func (t *RBTree[T]) lowerBound(key T) (ans *RBNode[T]) {
 	return
 }
 
-func (t *RBTree[T]) EraseKey(key T) bool {
+// Find returns the first node whose key equals key, or nil if there is none.
+func (t *RBTree[T]) Find(key T) *RBNode[T] {
 	p := t.lowerBound(key)
-	if p == nil || p.key != key {
+	if p == nil || t.cmp(p.key, key) != 0 {
+		return nil
+	}
+	return p
+}
+
+// Contains reports whether key is in the tree.
+func (t *RBTree[T]) Contains(key T) bool {
+	return t.Find(key) != nil
+}
+
+// EraseKey removes one key equal to key and reports whether one was found.
+func (t *RBTree[T]) EraseKey(key T) bool {
+	p := t.Find(key)
+	if p == nil {
 		return false
 	}
 	t.erase(p)
 	return true
 }
 
+// swapWithSuccessor exchanges the positions of p and its successor s,
+// which is the leftmost node of p's right subtree. The keys stay in their
+// nodes, so pointers to either node remain valid.
+func (t *RBTree[T]) swapWithSuccessor(p, s *RBNode[T]) {
+	pp, pl, sr := p.parent, p.getChild(DIR_LEFT), s.getChild(DIR_RIGHT)
+	if pp == nil {
+		t.root = s
+	} else {
+		pp.setChild(p.childDir(), s)
+	}
+	if s == p.getChild(DIR_RIGHT) {
+		s.setChild(DIR_RIGHT, p)
+		p.parent = s
+	} else {
+		sp, pr := s.parent, p.getChild(DIR_RIGHT)
+		sp.setChild(DIR_LEFT, p)
+		p.parent = sp
+		s.setChild(DIR_RIGHT, pr)
+		pr.parent = s
+	}
+	s.parent = pp
+	s.setChild(DIR_LEFT, pl)
+	pl.parent = s
+	p.setChild(DIR_LEFT, nil)
+	p.setChild(DIR_RIGHT, sr)
+	if sr != nil {
+		sr.parent = p
+	}
+	s.color, p.color = p.color, s.color
+	s.size, p.size = p.size, s.size
+}
+
+// erase removes p from the tree and returns the node holding the next key.
+// Like std::set::erase, a node with two children is first swapped with its
+// successor, which then takes over p's place in the tree. No key moves
+// between nodes, so every node other than p stays valid.
 func (t *RBTree[T]) erase(p *RBNode[T]) (res *RBNode[T]) {
 	if p == nil {
 		return nil
 	}
 	if p.hasChild(DIR_LEFT) && p.hasChild(DIR_RIGHT) {
-		s := leftmost(p)
-		s.key, p.key = p.key, s.key
-		res, p = p, s
+		res = leftmost(p.getChild(DIR_RIGHT))
+		t.swapWithSuccessor(p, res)
 	} else {
 		res = t.next(p)
 	}
@@ -255,30 +347,25 @@ func (t *RBTree[T]) erase(p *RBNode[T]) (res *RBNode[T]) {
 			t.root = s
 			return
 		}
-		_p.setChild(_p.childDir(), s)
-		now := _p
-		for now != nil {
+		_p.setChild(n.childDir(), s)
+		for now := _p; now != nil; now = now.parent {
 			now.size -= 1
-			now = now.parent
+			t.update(now)
 		}
 	}
 	eraseFixupBranchOrLeaf := func(n *RBNode[T]) {
-		nDir := Ternary(n == t.root, DIR_LEFT, p.childDir())
+		pp, nDir := n.parent, DIR_LEFT
+		if pp != nil {
+			nDir = n.childDir()
+		}
 		eraseFixupOrLeaf(n)
-		pp := n.parent
-		if pp == nil {
-			if t.root != nil {
-				t.root.color = BLACK
-			}
+		if n.color == RED {
 			return
-		} else {
-			s := pp.getChild(nDir)
-			if s != nil {
-				s.color = BLACK
-				return
-			}
 		}
-		for pp != nil && n.color == BLACK {
+		// n is black, its only child takes its place and restores the
+		// black height if it is red
+		n = Ternary(n.hasChild(DIR_LEFT), n.getChild(DIR_LEFT), n.getChild(DIR_RIGHT))
+		for pp != nil && !isRed(n) {
 			s := pp.getChild(!nDir)
 			// Case 1: s is red
 			//    p               s
@@ -304,8 +391,11 @@ func (t *RBTree[T]) erase(p *RBNode[T]) (res *RBNode[T]) {
 			if !isRed(c) && !isRed(d) {
 				s.color = RED
 				n = pp
-				goto end_erase_fixup
-
+				pp = n.parent
+				if pp != nil {
+					nDir = n.childDir()
+				}
+				continue
 			}
 			// Case 3: c is red and d is black
 			//   {p}          {p}
@@ -320,7 +410,6 @@ func (t *RBTree[T]) erase(p *RBNode[T]) (res *RBNode[T]) {
 				s.color = RED
 				t.roate(s, !nDir)
 				s = pp.getChild(!nDir)
-				c = s.getChild(nDir)
 				d = s.getChild(!nDir)
 			}
 			// Case 4: d is red
@@ -334,15 +423,16 @@ func (t *RBTree[T]) erase(p *RBNode[T]) (res *RBNode[T]) {
 			d.color = BLACK
 			t.roate(pp, nDir)
 			n = t.root
-		end_erase_fixup:
-			pp = n.parent
-			if pp == nil {
-				break
-			}
-			nDir = n.childDir()
+			break
+		}
+		if n != nil {
+			n.color = BLACK
 		}
-		n.color = BLACK
 	}
 	eraseFixupBranchOrLeaf(p)
+	p.parent, p.child = nil, [2]*RBNode[T]{nil, nil}
+	if t.pool != nil {
+		t.pool.put(p)
+	}
 	return
 }
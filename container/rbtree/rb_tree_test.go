@@ -0,0 +1,71 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func keys[T any](t *RBTree[T]) []T {
+	var res []T
+	t.InOrder(func(node *RBNode[T]) {
+		res = append(res, node.Key())
+	})
+	return res
+}
+
+func TestInsertAndErase(t *testing.T) {
+	tree := New[int]()
+	want := rand.Perm(1000)
+	for _, k := range want {
+		tree.Insert(k)
+	}
+	if tree.Len() != len(want) {
+		t.Fatalf("Len() = %d; want %d", tree.Len(), len(want))
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Validate() after insert: %v", err)
+	}
+	slices.Sort(want)
+	if got := keys(tree); !slices.Equal(got, want) {
+		t.Fatalf("InOrder() = %v; want %v", got, want)
+	}
+
+	for i, k := range rand.Perm(1000) {
+		if i%2 == 0 {
+			continue
+		}
+		if !tree.EraseKey(k) {
+			t.Fatalf("EraseKey(%d) = false; want true", k)
+		}
+		want = slices.DeleteFunc(want, func(v int) bool { return v == k })
+	}
+	if tree.EraseKey(-1) {
+		t.Errorf("EraseKey(-1) = true; want false")
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Validate() after erase: %v", err)
+	}
+	if got := keys(tree); !slices.Equal(got, want) {
+		t.Fatalf("InOrder() = %v; want %v", got, want)
+	}
+	for _, k := range want {
+		if !tree.Contains(k) {
+			t.Errorf("Contains(%d) = false; want true", k)
+		}
+	}
+}
+
+func TestDuplicateKeys(t *testing.T) {
+	tree := New[int]()
+	for _, k := range []int{3, 1, 3, 2, 3} {
+		tree.Insert(k)
+	}
+	if got, want := keys(tree), []int{1, 2, 3, 3, 3}; !slices.Equal(got, want) {
+		t.Errorf("InOrder() = %v; want %v", got, want)
+	}
+	tree.EraseKey(3)
+	if got, want := keys(tree), []int{1, 2, 3, 3}; !slices.Equal(got, want) {
+		t.Errorf("InOrder() = %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package rbtree
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDOT writes the tree as a Graphviz digraph. Nodes are filled with
+// their colour and labelled with their key and subtree size, edges are
+// labelled L or R.
+func (t *RBTree[T]) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	ids := make(map[*RBNode[T]]int)
+	sb.WriteString("digraph rbtree {\n")
+	sb.WriteString("\tnode [shape=circle, style=filled, fontcolor=white];\n")
+	t.PreOrder(func(node *RBNode[T]) {
+		id := len(ids)
+		ids[node] = id
+		label := strings.ReplaceAll(fmt.Sprintf("%v", node.key), `"`, `\"`)
+		fmt.Fprintf(&sb, "\tn%d [label=\"%s\\nsize=%d\", fillcolor=%s];\n", id, label, node.size, node.color)
+		if node.parent != nil {
+			fmt.Fprintf(&sb, "\tn%d -> n%d [label=%s];\n", ids[node.parent], id, Ternary(node.childDir(), "R", "L"))
+		}
+	})
+	sb.WriteString("}\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// String draws the tree sideways, one node per line in pre-order with its
+// children indented below it, for example
+//
+//	2 (black, 3)
+//	├── L 1 (red, 1)
+//	└── R 3 (red, 1)
+func (t *RBTree[T]) String() string {
+	var sb strings.Builder
+	t.PreOrder(func(node *RBNode[T]) {
+		// the indent of every ancestor depends on whether it was the last
+		// child of its own parent, collected from the bottom up
+		var indent []string
+		for p := node.parent; p != nil && p.parent != nil; p = p.parent {
+			indent = append(indent, Ternary(isLastChild(p), "    ", "│   "))
+		}
+		for i := len(indent) - 1; i >= 0; i-- {
+			sb.WriteString(indent[i])
+		}
+		if node.parent != nil {
+			sb.WriteString(Ternary(isLastChild(node), "└── ", "├── "))
+			sb.WriteString(Ternary(node.childDir(), "R ", "L "))
+		}
+		fmt.Fprintf(&sb, "%v (%s, %d)\n", node.key, node.color, node.size)
+	})
+	return sb.String()
+}
+
+// isLastChild reports whether n is the last child its parent prints.
+func isLastChild[T any](n *RBNode[T]) bool {
+	return n.childDir() == DIR_RIGHT || !n.parent.hasChild(DIR_RIGHT)
+}
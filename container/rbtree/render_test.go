@@ -0,0 +1,49 @@
+package rbtree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tree := New[int]()
+	if got := tree.String(); got != "" {
+		t.Errorf("String() of an empty tree = %q; want empty", got)
+	}
+	for _, k := range []int{2, 1, 4, 3, 5, 6} {
+		tree.Insert(k)
+	}
+	want := `2 (black, 6)
+├── L 1 (black, 1)
+└── R 4 (red, 4)
+    ├── L 3 (black, 1)
+    └── R 5 (black, 2)
+        └── R 6 (red, 1)
+`
+	if got := tree.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree := New[string]()
+	for _, k := range []string{"b", "a", `"c"`} {
+		tree.Insert(k)
+	}
+	var sb strings.Builder
+	if err := tree.WriteDOT(&sb); err != nil {
+		t.Fatalf("WriteDOT(): %v", err)
+	}
+	want := `digraph rbtree {
+	node [shape=circle, style=filled, fontcolor=white];
+	n0 [label="a\nsize=3", fillcolor=black];
+	n1 [label="\"c\"\nsize=1", fillcolor=red];
+	n0 -> n1 [label=L];
+	n2 [label="b\nsize=1", fillcolor=red];
+	n0 -> n2 [label=R];
+}
+`
+	if got := sb.String(); got != want {
+		t.Errorf("WriteDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
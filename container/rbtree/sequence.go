@@ -0,0 +1,159 @@
+package rbtree
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Sequence is a list indexed by position. Its nodes are ordered by where
+// they sit rather than by a key, and the subtree sizes turn a position into
+// a path, so every operation below runs in O(log n). It suits editor
+// buffers and other lists that need fast inserts in the middle.
+type Sequence[T any] struct {
+	tree RBTree[T]
+}
+
+// NewSequence returns a sequence holding values in order, built in O(n).
+// The values slice is not modified.
+func NewSequence[T any](values []T, opts ...Option) *Sequence[T] {
+	s := &Sequence[T]{}
+	s.tree.setOptions(opts)
+	s.tree.setRoot(s.tree.build(values))
+	return s
+}
+
+func (s *Sequence[T]) checkIndex(i, n int) {
+	if i < 0 || i >= n {
+		panic(fmt.Sprintf("rbtree: index %d out of range [0:%d]", i, n))
+	}
+}
+
+func (s *Sequence[T]) checkSlice(i, j int) {
+	if i < 0 || i > j || j > s.Len() {
+		panic(fmt.Sprintf("rbtree: slice bounds [%d:%d] out of range [0:%d]", i, j, s.Len()))
+	}
+}
+
+// Len returns the number of values.
+func (s *Sequence[T]) Len() int {
+	return s.tree.Len()
+}
+
+// At returns the value at position i. It panics if i is out of range.
+func (s *Sequence[T]) At(i int) T {
+	s.checkIndex(i, s.Len())
+	return s.tree.Select(i).key
+}
+
+// Set replaces the value at position i. It panics if i is out of range.
+func (s *Sequence[T]) Set(i int, v T) {
+	s.checkIndex(i, s.Len())
+	s.tree.Select(i).key = v
+}
+
+// InsertAt inserts v so that it ends up at position i, shifting the values
+// from i on one place up. i may equal Len to append.
+func (s *Sequence[T]) InsertAt(i int, v T) {
+	s.checkIndex(i, s.Len()+1)
+	n := s.tree.newNode(v)
+	if i == s.Len() {
+		s.tree.insertAt(n, rightmost(s.tree.root), DIR_RIGHT)
+		return
+	}
+	// the new node goes right before the current node at i: as its left
+	// child if it has none, else right of its predecessor
+	p := s.tree.Select(i)
+	if !p.hasChild(DIR_LEFT) {
+		s.tree.insertAt(n, p, DIR_LEFT)
+		return
+	}
+	s.tree.insertAt(n, rightmost(p.getChild(DIR_LEFT)), DIR_RIGHT)
+}
+
+// DeleteAt removes the value at position i and returns it.
+// It panics if i is out of range.
+func (s *Sequence[T]) DeleteAt(i int) T {
+	s.checkIndex(i, s.Len())
+	n := s.tree.Select(i)
+	v := n.key
+	s.tree.erase(n)
+	return v
+}
+
+// Split moves the values from position i on into a new sequence and
+// returns it, s keeps the first i values.
+func (s *Sequence[T]) Split(i int) *Sequence[T] {
+	s.checkIndex(i, s.Len()+1)
+	l, r := s.tree.joiner().splitAt(newSubtree(s.tree.root), i)
+	s.tree.setRoot(l.root)
+	right := &Sequence[T]{tree: *s.tree.empty()}
+	right.tree.setRoot(r.root)
+	return right
+}
+
+// Cut removes the values at positions i to j-1 from s and returns them as
+// a new sequence, the values after j move down to close the gap.
+func (s *Sequence[T]) Cut(i, j int) *Sequence[T] {
+	s.checkSlice(i, j)
+	jn := s.tree.joiner()
+	rest, r := jn.splitAt(newSubtree(s.tree.root), j)
+	l, mid := jn.splitAt(rest, i)
+	s.tree.setRoot(jn.join2(l, r).root)
+	cut := &Sequence[T]{tree: *s.tree.empty()}
+	cut.tree.setRoot(mid.root)
+	return cut
+}
+
+// Slice returns a new sequence holding a copy of the values at positions
+// i to j-1, s is not modified. It runs in O(j-i+log n).
+func (s *Sequence[T]) Slice(i, j int) *Sequence[T] {
+	s.checkSlice(i, j)
+	values := make([]T, 0, j-i)
+	for v := range s.Range(i, j) {
+		values = append(values, v)
+	}
+	cp := &Sequence[T]{tree: *s.tree.empty()}
+	cp.tree.setRoot(cp.tree.build(values))
+	return cp
+}
+
+// Concat appends the values of other to s and leaves other empty.
+func (s *Sequence[T]) Concat(other *Sequence[T]) {
+	s.tree.Join(&other.tree)
+}
+
+// Splice inserts the values of other at position i and leaves other empty.
+func (s *Sequence[T]) Splice(i int, other *Sequence[T]) {
+	right := s.Split(i)
+	s.Concat(other)
+	s.Concat(right)
+}
+
+// All returns an iterator over the values in order.
+func (s *Sequence[T]) All() iter.Seq[T] {
+	return s.tree.All()
+}
+
+// Backward returns an iterator over the values in reverse order.
+func (s *Sequence[T]) Backward() iter.Seq[T] {
+	return s.tree.Backward()
+}
+
+// Range returns an iterator over the values at positions i to j-1. The
+// bounds are checked again each time the iterator starts, as s may have
+// shrunk since.
+func (s *Sequence[T]) Range(i, j int) iter.Seq[T] {
+	s.checkSlice(i, j)
+	return func(yield func(T) bool) {
+		s.checkSlice(i, j)
+		n := s.tree.Select(i)
+		for k := i; k < j && yield(n.key); k++ {
+			n = s.tree.next(n)
+		}
+	}
+}
+
+// Values returns the values in order.
+func (s *Sequence[T]) Values() []T {
+	return s.tree.ToSlice()
+}
@@ -0,0 +1,122 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func checkSequence(t *testing.T, name string, s *Sequence[int], want []int) {
+	t.Helper()
+	if err := s.tree.Validate(); err != nil {
+		t.Fatalf("%s: Validate(): %v", name, err)
+	}
+	if got := s.Values(); !slices.Equal(got, want) {
+		t.Fatalf("%s = %v; want %v", name, got, want)
+	}
+	if s.Len() != len(want) {
+		t.Fatalf("%s: Len() = %d; want %d", name, s.Len(), len(want))
+	}
+}
+
+func TestSequence(t *testing.T) {
+	s := NewSequence[int](nil)
+	var want []int
+	for i := 0; i < 3000; i++ {
+		switch op := rand.Intn(4); {
+		case op == 0 && len(want) > 0:
+			at := rand.Intn(len(want))
+			if got := s.DeleteAt(at); got != want[at] {
+				t.Fatalf("DeleteAt(%d) = %d; want %d", at, got, want[at])
+			}
+			want = slices.Delete(want, at, at+1)
+		case op == 1 && len(want) > 0:
+			at := rand.Intn(len(want))
+			if got := s.At(at); got != want[at] {
+				t.Fatalf("At(%d) = %d; want %d", at, got, want[at])
+			}
+			s.Set(at, -i)
+			want[at] = -i
+		default:
+			at := rand.Intn(len(want) + 1)
+			s.InsertAt(at, i)
+			want = slices.Insert(want, at, i)
+		}
+	}
+	checkSequence(t, "random edits", s, want)
+
+	i, j := len(want)/3, 2*len(want)/3
+	// the iterator must be reusable
+	seq := s.Range(i, j)
+	for pass := 0; pass < 2; pass++ {
+		if got := slices.Collect(seq); !slices.Equal(got, want[i:j]) {
+			t.Errorf("pass %d over Range(%d, %d) = %v; want %v", pass, i, j, got, want[i:j])
+		}
+	}
+	rev := slices.Clone(want)
+	slices.Reverse(rev)
+	if got := slices.Collect(s.Backward()); !slices.Equal(got, rev) {
+		t.Errorf("Backward() does not match the reversed values")
+	}
+}
+
+func TestSequenceCutAndConcat(t *testing.T) {
+	for n := 0; n < 40; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i
+		}
+		for i := 0; i <= n; i++ {
+			for j := i; j <= n; j++ {
+				s := NewSequence(values)
+				cp := s.Slice(i, j)
+				checkSequence(t, "Slice", cp, values[i:j])
+				checkSequence(t, "sliced", s, values)
+				cp.InsertAt(0, -1)
+				checkSequence(t, "sliced after changing the copy", s, values)
+
+				cut := s.Cut(i, j)
+				checkSequence(t, "cut", cut, values[i:j])
+				checkSequence(t, "rest", s, slices.Concat(values[:i], values[j:]))
+
+				s.Splice(i, cut)
+				checkSequence(t, "Splice", s, values)
+				checkSequence(t, "spliced", cut, nil)
+			}
+		}
+		s := NewSequence(values)
+		right := s.Split(n / 2)
+		checkSequence(t, "left of Split", s, values[:n/2])
+		checkSequence(t, "right of Split", right, values[n/2:])
+		right.Concat(s)
+		checkSequence(t, "Concat", right, slices.Concat(values[n/2:], values[:n/2]))
+	}
+}
+
+func TestSequenceOutOfRange(t *testing.T) {
+	s := NewSequence([]int{1, 2, 3})
+	for name, f := range map[string]func(){
+		"At(3)":        func() { s.At(3) },
+		"InsertAt(-1)": func() { s.InsertAt(-1, 0) },
+		"DeleteAt(3)":  func() { s.DeleteAt(3) },
+		"Slice(2, 1)":  func() { s.Slice(2, 1) },
+		"Cut(0, 4)":    func() { s.Cut(0, 4) },
+		"Range(0, 4)":  func() { s.Range(0, 4) },
+		"Range(0, 3) after a delete": func() {
+			s := NewSequence([]int{1, 2, 3})
+			seq := s.Range(0, 3)
+			s.DeleteAt(0)
+			for range seq {
+			}
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
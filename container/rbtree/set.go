@@ -0,0 +1,90 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// TreeSet is an ordered set in which every key appears at most once.
+// It shares all the queries of RBTree. Insert, Split, Join and the decoders
+// differ so that the keys stay unique.
+type TreeSet[T any] struct {
+	*RBTree[T]
+}
+
+// NewTreeSet returns an empty set ordered by cmp.Compare.
+func NewTreeSet[T cmp.Ordered](opts ...Option) *TreeSet[T] {
+	return &TreeSet[T]{New[T](opts...)}
+}
+
+// NewTreeSetFunc returns an empty set ordered by cmp.
+func NewTreeSetFunc[T any](cmp func(a, b T) int, opts ...Option) *TreeSet[T] {
+	return &TreeSet[T]{NewFunc(cmp, opts...)}
+}
+
+// Insert adds key to the set and reports whether it was already present,
+// in which case the set is not modified.
+func (s *TreeSet[T]) Insert(key T) bool {
+	now := s.root
+	dir := DIR_LEFT
+	var p *RBNode[T]
+	for now != nil {
+		c := s.cmp(key, now.key)
+		if c == 0 {
+			return true
+		}
+		p = now
+		dir = c > 0
+		now = now.getChild(dir)
+	}
+	s.insertAt(s.newNode(key), p, dir)
+	return false
+}
+
+// Delete removes key from the set and reports whether it was present.
+func (s *TreeSet[T]) Delete(key T) bool {
+	return s.EraseKey(key)
+}
+
+// TreeMultiSet is an ordered set that keeps every inserted key,
+// equal keys are kept in insertion order.
+type TreeMultiSet[T any] struct {
+	*RBTree[T]
+}
+
+// NewTreeMultiSet returns an empty multiset ordered by cmp.Compare.
+func NewTreeMultiSet[T cmp.Ordered](opts ...Option) *TreeMultiSet[T] {
+	return &TreeMultiSet[T]{New[T](opts...)}
+}
+
+// NewTreeMultiSetFunc returns an empty multiset ordered by cmp.
+func NewTreeMultiSetFunc[T any](cmp func(a, b T) int, opts ...Option) *TreeMultiSet[T] {
+	return &TreeMultiSet[T]{NewFunc(cmp, opts...)}
+}
+
+// Count returns the number of keys equal to key.
+func (s *TreeMultiSet[T]) Count(key T) int {
+	return s.CountRange(key, key, RANGE_CLOSED)
+}
+
+// EqualRange returns an iterator over the keys equal to key in insertion order.
+func (s *TreeMultiSet[T]) EqualRange(key T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.Range(key, key, RANGE_CLOSED, func(n *RBNode[T]) bool {
+			return yield(n.key)
+		})
+	}
+}
+
+// EraseOne removes the first key equal to key and reports whether one was found.
+func (s *TreeMultiSet[T]) EraseOne(key T) bool {
+	return s.EraseKey(key)
+}
+
+// EraseAll removes every key equal to key and returns how many were removed.
+func (s *TreeMultiSet[T]) EraseAll(key T) (cnt int) {
+	for n := s.lowerBound(key); n != nil && s.cmp(n.key, key) == 0; cnt++ {
+		n = s.erase(n)
+	}
+	return
+}
@@ -0,0 +1,65 @@
+package rbtree
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTreeSet(t *testing.T) {
+	s := NewTreeSet[int]()
+	for _, k := range []int{3, 1, 2} {
+		if s.Insert(k) {
+			t.Errorf("Insert(%d) = true; want false", k)
+		}
+	}
+	if !s.Insert(2) {
+		t.Errorf("Insert(2) = false; want true")
+	}
+	if s.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", s.Len())
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All() = %v; want [1 2 3]", got)
+	}
+	if !s.EraseKey(2) || s.Contains(2) {
+		t.Errorf("EraseKey(2) should remove 2")
+	}
+}
+
+func TestTreeMultiSet(t *testing.T) {
+	type item struct {
+		key, tag string
+	}
+	s := NewTreeMultiSetFunc(func(a, b item) int {
+		return strings.Compare(a.key, b.key)
+	})
+	for _, it := range []item{{"b", "1"}, {"a", "1"}, {"b", "2"}, {"c", "1"}, {"b", "3"}} {
+		s.Insert(it)
+	}
+	if got := s.Count(item{key: "b"}); got != 3 {
+		t.Errorf("Count(b) = %d; want 3", got)
+	}
+	if got := s.Count(item{key: "d"}); got != 0 {
+		t.Errorf("Count(d) = %d; want 0", got)
+	}
+	var tags []string
+	for it := range s.EqualRange(item{key: "b"}) {
+		tags = append(tags, it.tag)
+	}
+	if !slices.Equal(tags, []string{"1", "2", "3"}) {
+		t.Errorf("EqualRange(b) tags = %v; want [1 2 3]", tags)
+	}
+	if !s.EraseOne(item{key: "b"}) || s.Count(item{key: "b"}) != 2 {
+		t.Errorf("EraseOne(b) should leave two b keys")
+	}
+	if got := s.EraseAll(item{key: "b"}); got != 2 {
+		t.Errorf("EraseAll(b) = %d; want 2", got)
+	}
+	if got := s.EraseAll(item{key: "b"}); got != 0 {
+		t.Errorf("EraseAll(b) = %d; want 0", got)
+	}
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d; want 2", s.Len())
+	}
+}
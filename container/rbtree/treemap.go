@@ -0,0 +1,146 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+)
+
+type entry[K, V any] struct {
+	key   K
+	value V
+}
+
+// TreeMap is an ordered key/value map backed by a red-black tree.
+// Every key appears at most once.
+type TreeMap[K, V any] struct {
+	tree *RBTree[entry[K, V]]
+}
+
+// NewTreeMap returns an empty map ordered by cmp.Compare.
+func NewTreeMap[K cmp.Ordered, V any](opts ...Option) *TreeMap[K, V] {
+	return NewTreeMapFunc[K, V](cmp.Compare[K], opts...)
+}
+
+// NewTreeMapFunc returns an empty map whose keys are ordered by cmp.
+func NewTreeMapFunc[K, V any](cmp func(a, b K) int, opts ...Option) *TreeMap[K, V] {
+	return &TreeMap[K, V]{
+		tree: NewFunc(func(a, b entry[K, V]) int {
+			return cmp(a.key, b.key)
+		}, opts...),
+	}
+}
+
+// Len returns the number of keys in the map.
+func (m *TreeMap[K, V]) Len() int {
+	return m.tree.Len()
+}
+
+// Put associates value with key and reports whether key was already present.
+func (m *TreeMap[K, V]) Put(key K, value V) bool {
+	if n := m.tree.Find(entry[K, V]{key: key}); n != nil {
+		n.key.value = value
+		return true
+	}
+	m.tree.Insert(entry[K, V]{key: key, value: value})
+	return false
+}
+
+// Get returns the value associated with key and whether it was found.
+func (m *TreeMap[K, V]) Get(key K) (V, bool) {
+	if n := m.tree.Find(entry[K, V]{key: key}); n != nil {
+		return n.key.value, true
+	}
+	var v V
+	return v, false
+}
+
+// Contains reports whether key is in the map.
+func (m *TreeMap[K, V]) Contains(key K) bool {
+	return m.tree.Contains(entry[K, V]{key: key})
+}
+
+// Delete removes key from the map and reports whether it was present.
+func (m *TreeMap[K, V]) Delete(key K) bool {
+	return m.tree.EraseKey(entry[K, V]{key: key})
+}
+
+func (m *TreeMap[K, V]) unpack(n *RBNode[entry[K, V]]) (K, V, bool) {
+	if n == nil {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	return n.key.key, n.key.value, true
+}
+
+// Min returns the smallest key and its value, the bool is false if the map is empty.
+func (m *TreeMap[K, V]) Min() (K, V, bool) {
+	return m.unpack(m.tree.Min())
+}
+
+// Max returns the largest key and its value, the bool is false if the map is empty.
+func (m *TreeMap[K, V]) Max() (K, V, bool) {
+	return m.unpack(m.tree.Max())
+}
+
+// Floor returns the greatest key less than or equal to key.
+func (m *TreeMap[K, V]) Floor(key K) (K, V, bool) {
+	return m.unpack(m.tree.Floor(entry[K, V]{key: key}))
+}
+
+// Ceiling returns the least key greater than or equal to key.
+func (m *TreeMap[K, V]) Ceiling(key K) (K, V, bool) {
+	return m.unpack(m.tree.Ceiling(entry[K, V]{key: key}))
+}
+
+// Lower returns the greatest key strictly less than key.
+func (m *TreeMap[K, V]) Lower(key K) (K, V, bool) {
+	return m.unpack(m.tree.Lower(entry[K, V]{key: key}))
+}
+
+// Higher returns the least key strictly greater than key.
+func (m *TreeMap[K, V]) Higher(key K) (K, V, bool) {
+	return m.unpack(m.tree.Higher(entry[K, V]{key: key}))
+}
+
+// Range calls fn for every key between lo and hi in ascending order,
+// see RBTree.Range for the meaning of mode.
+func (m *TreeMap[K, V]) Range(lo, hi K, mode RangeMode, fn func(key K, value V) bool) {
+	m.tree.Range(entry[K, V]{key: lo}, entry[K, V]{key: hi}, mode, func(n *RBNode[entry[K, V]]) bool {
+		return fn(n.key.key, n.key.value)
+	})
+}
+
+func (m *TreeMap[K, V]) pairs(keys iter.Seq[entry[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for e := range keys {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the key/value pairs in ascending key order.
+func (m *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return m.pairs(m.tree.All())
+}
+
+// Backward returns an iterator over the key/value pairs in descending key order.
+func (m *TreeMap[K, V]) Backward() iter.Seq2[K, V] {
+	return m.pairs(m.tree.Backward())
+}
+
+// Ascend returns an iterator over the pairs whose key is greater than or
+// equal to from, in ascending key order.
+func (m *TreeMap[K, V]) Ascend(from K) iter.Seq2[K, V] {
+	return m.pairs(m.tree.Ascend(entry[K, V]{key: from}))
+}
+
+// Descend returns an iterator over the pairs whose key is less than or
+// equal to from, in descending key order.
+func (m *TreeMap[K, V]) Descend(from K) iter.Seq2[K, V] {
+	return m.pairs(m.tree.Descend(entry[K, V]{key: from}))
+}
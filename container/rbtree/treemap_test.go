@@ -0,0 +1,42 @@
+package rbtree
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTreeMap(t *testing.T) {
+	m := NewTreeMap[string, int]()
+	if m.Put("b", 2) {
+		t.Errorf("Put(b) = true; want false")
+	}
+	m.Put("a", 1)
+	m.Put("c", 3)
+	if !m.Put("b", 20) {
+		t.Errorf("Put(b) = false; want true")
+	}
+	if m.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", m.Len())
+	}
+	if v, ok := m.Get("b"); !ok || v != 20 {
+		t.Errorf("Get(b) = %d, %v; want 20, true", v, ok)
+	}
+	if _, ok := m.Get("d"); ok {
+		t.Errorf("Get(d) found a value; want none")
+	}
+	if !m.Delete("a") || m.Delete("a") {
+		t.Errorf("Delete(a) should succeed exactly once")
+	}
+	if m.Contains("a") || !m.Contains("c") {
+		t.Errorf("Contains() disagrees with the map contents")
+	}
+}
+
+func TestTreeMapFunc(t *testing.T) {
+	m := NewTreeMapFunc[string, int](strings.Compare)
+	m.Put("x", 1)
+	m.Put("y", 2)
+	if v, _ := m.Get("y"); v != 2 {
+		t.Errorf("Get(y) = %d; want 2", v)
+	}
+}
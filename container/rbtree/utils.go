@@ -7,6 +7,17 @@ const (
 	RED
 )
 
+func (c Color) String() string {
+	switch c {
+	case BLACK:
+		return "black"
+	case RED:
+		return "red"
+	default:
+		return "unknown"
+	}
+}
+
 const (
 	DIR_LEFT  = false
 	DIR_RIGHT = true
@@ -16,14 +27,21 @@ func btoi(b bool) int {
 	return Ternary(b, 1, 0)
 }
 
-func isRed[T comparable](node *RBNode[T]) bool {
+func isRed[T any](node *RBNode[T]) bool {
 	if node == nil {
 		return false
 	}
 	return Ternary(node == nil, false, node.color == RED)
 }
 
-func most[T comparable](p *RBNode[T], dir bool) *RBNode[T] {
+func getSize[T any](node *RBNode[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+func most[T any](p *RBNode[T], dir bool) *RBNode[T] {
 	if p == nil {
 		return nil
 	}
@@ -34,11 +52,11 @@ func most[T comparable](p *RBNode[T], dir bool) *RBNode[T] {
 	return q
 }
 
-func leftmost[T comparable](p *RBNode[T]) *RBNode[T] {
+func leftmost[T any](p *RBNode[T]) *RBNode[T] {
 	return most(p, DIR_LEFT)
 }
 
-func rightmost[T comparable](p *RBNode[T]) *RBNode[T] {
+func rightmost[T any](p *RBNode[T]) *RBNode[T] {
 	return most(p, DIR_RIGHT)
 }
 
@@ -47,7 +65,7 @@ func (t *RBTree[T]) neighbour(p *RBNode[T], dir bool) *RBNode[T] {
 		return nil
 	}
 	if p.getChild(dir) != nil {
-		return most(p.getChild(dir), dir)
+		return most(p.getChild(dir), !dir)
 	}
 	if p == t.root {
 		return nil
@@ -0,0 +1,73 @@
+package rbtree
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidTree = errors.New("invalid red-black tree")
+)
+
+// Validate checks the red-black rules listed in the package comment together
+// with the bookkeeping the tree relies on: parent links, subtree sizes and
+// key order, unless the tree has no comparator as in Sequence. It returns
+// an error wrapping ErrInvalidTree for the first violation found, or nil if
+// the tree is valid.
+func (t *RBTree[T]) Validate() error {
+	return t.validate(true)
+}
+
+// validate is Validate with the parent link checks made optional for trees
+// whose nodes are shared and carry no parent links.
+func (t *RBTree[T]) validate(parentLinks bool) error {
+	if t.root == nil {
+		return nil
+	}
+	if parentLinks && t.root.parent != nil {
+		return fmt.Errorf("%w: root has a parent", ErrInvalidTree)
+	}
+	if isRed(t.root) {
+		return fmt.Errorf("%w: root is red", ErrInvalidTree)
+	}
+	var prev *RBNode[T]
+	var validate func(n *RBNode[T]) (int, error)
+	validate = func(n *RBNode[T]) (int, error) {
+		if n == nil {
+			return 1, nil
+		}
+		for _, dir := range []bool{DIR_LEFT, DIR_RIGHT} {
+			c := n.getChild(dir)
+			if c == nil {
+				continue
+			}
+			if parentLinks && c.parent != n {
+				return 0, fmt.Errorf("%w: broken parent link below %v", ErrInvalidTree, n.key)
+			}
+			if isRed(n) && isRed(c) {
+				return 0, fmt.Errorf("%w: red node %v has a red child", ErrInvalidTree, n.key)
+			}
+		}
+		lh, err := validate(n.getChild(DIR_LEFT))
+		if err != nil {
+			return 0, err
+		}
+		if prev != nil && t.cmp != nil && t.cmp(prev.key, n.key) > 0 {
+			return 0, fmt.Errorf("%w: key %v is placed before %v", ErrInvalidTree, prev.key, n.key)
+		}
+		prev = n
+		rh, err := validate(n.getChild(DIR_RIGHT))
+		if err != nil {
+			return 0, err
+		}
+		if lh != rh {
+			return 0, fmt.Errorf("%w: black heights differ below %v", ErrInvalidTree, n.key)
+		}
+		if want := getSize(n.getChild(DIR_LEFT)) + getSize(n.getChild(DIR_RIGHT)) + 1; n.size != want {
+			return 0, fmt.Errorf("%w: size of %v is %d, want %d", ErrInvalidTree, n.key, n.size, want)
+		}
+		return lh + Ternary(isRed(n), 0, 1), nil
+	}
+	_, err := validate(t.root)
+	return err
+}
@@ -0,0 +1,106 @@
+package rbtree
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tree := New[int]()
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Validate() on an empty tree: %v", err)
+	}
+	for i := 0; i < 16; i++ {
+		tree.Insert(i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("Validate(): %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func(tree *RBTree[int]) func()
+	}{
+		{"red root", func(tree *RBTree[int]) func() {
+			tree.root.color = RED
+			return func() { tree.root.color = BLACK }
+		}},
+		{"bad size", func(tree *RBTree[int]) func() {
+			n := tree.Min()
+			n.size++
+			return func() { n.size-- }
+		}},
+		{"bad parent", func(tree *RBTree[int]) func() {
+			n := tree.Max()
+			p := n.parent
+			n.parent = tree.root.getChild(DIR_LEFT)
+			return func() { n.parent = p }
+		}},
+		{"bad order", func(tree *RBTree[int]) func() {
+			a, b := tree.Min(), tree.Max()
+			a.key, b.key = b.key, a.key
+			return func() { a.key, b.key = b.key, a.key }
+		}},
+		{"bad black height", func(tree *RBTree[int]) func() {
+			n := tree.Min()
+			c, pc := n.color, n.parent.color
+			n.color = Ternary(c == RED, BLACK, RED)
+			if n.color == RED && isRed(n.parent) {
+				n.parent.color = BLACK
+			}
+			return func() { n.color, n.parent.color = c, pc }
+		}},
+	}
+	// every case corrupts the shared tree and restores it afterwards,
+	// so the tree must be valid again before the next case runs
+	for _, tt := range tests {
+		restore := tt.corrupt(tree)
+		if err := tree.Validate(); !errors.Is(err, ErrInvalidTree) {
+			t.Errorf("%s: Validate() = %v; want ErrInvalidTree", tt.name, err)
+		}
+		restore()
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("%s: Validate() after restoring: %v", tt.name, err)
+		}
+	}
+}
+
+// FuzzRBTree replays random insert and erase sequences against a sorted
+// slice and checks the tree after every step.
+func FuzzRBTree(f *testing.F) {
+	f.Add([]byte{1, 2, 3, 4, 5, 0x81, 0x83})
+	f.Add([]byte{9, 9, 9, 0x89, 0x89, 0x89, 0x89})
+	f.Add([]byte{10, 20, 30, 40, 50, 60, 70, 0x9e, 0xa8, 0x8a})
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		tree := New[byte]()
+		var model []byte
+		for _, op := range ops {
+			key := op & 0x7f
+			if op&0x80 == 0 {
+				tree.Insert(key)
+				i, _ := slices.BinarySearch(model, key+1)
+				model = slices.Insert(model, i, key)
+			} else {
+				i, found := slices.BinarySearch(model, key)
+				if got := tree.EraseKey(key); got != found {
+					t.Fatalf("EraseKey(%d) = %v; want %v", key, got, found)
+				}
+				if found {
+					model = slices.Delete(model, i, i+1)
+				}
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("Validate() after op %#x: %v\n%s", op, err, tree)
+			}
+		}
+		if got := slices.Collect(tree.All()); !slices.Equal(got, model) {
+			t.Fatalf("All() = %v; want %v", got, model)
+		}
+		for i, k := range model {
+			if n := tree.Select(i); n == nil || n.Key() != k {
+				t.Fatalf("Select(%d) disagrees with the model", i)
+			}
+		}
+	})
+}
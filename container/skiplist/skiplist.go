@@ -0,0 +1,373 @@
+// package skiplist implements a concurrent ordered map on a lazy skip list
+// (Herlihy, Lev, Luchangco and Shavit, "A Simple Optimistic Skiplist
+// Algorithm"). Writers lock only the nodes around the key they change and
+// readers never lock at all, so the map scales with the number of cores
+// even under write-heavy load.
+//
+// Get, Contains, Put and Delete are linearizable. Min, Max, Floor and
+// Ceiling return a pair that was present at some point during the call, and
+// the iterators are weakly consistent: they yield keys in ascending order,
+// never twice, and see every key that is present for the whole iteration.
+package skiplist
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	maxLevel = 24
+	// a node reaches the next level with probability 1/4, which takes
+	// two random bits per level
+	levelBits = 2
+)
+
+// node is a tower of forward pointers. A node is logically in the list once
+// fullyLinked is set and until marked is set, both only ever go from false
+// to true. mu guards linking changes to next and stores to value.
+type node[K, V any] struct {
+	key         K
+	value       atomic.Pointer[V]
+	next        []atomic.Pointer[node[K, V]]
+	mu          sync.Mutex
+	marked      atomic.Bool
+	fullyLinked atomic.Bool
+}
+
+func (n *node[K, V]) topLevel() int {
+	return len(n.next)
+}
+
+// live reports whether n is logically in the list.
+func (n *node[K, V]) live() bool {
+	return n.fullyLinked.Load() && !n.marked.Load()
+}
+
+// SkipList is an ordered map that is safe for concurrent use.
+// The zero value is not usable, create one with New or NewFunc.
+type SkipList[K, V any] struct {
+	head *node[K, V]
+	len  atomic.Int64
+	cmp  func(a, b K) int
+}
+
+// New returns an empty skip list ordered by cmp.Compare.
+func New[K cmp.Ordered, V any]() *SkipList[K, V] {
+	return NewFunc[K, V](cmp.Compare[K])
+}
+
+// NewFunc returns an empty skip list whose keys are ordered by cmp.
+func NewFunc[K, V any](cmp func(a, b K) int) *SkipList[K, V] {
+	head := &node[K, V]{next: make([]atomic.Pointer[node[K, V]], maxLevel)}
+	head.fullyLinked.Store(true)
+	return &SkipList[K, V]{head: head, cmp: cmp}
+}
+
+func randomLevel() int {
+	level := bits.TrailingZeros64(rand.Uint64())/levelBits + 1
+	return min(level, maxLevel)
+}
+
+// find fills preds and succs with the last node before key and the node
+// after it on every level, the tail is nil. It returns the highest level
+// on which a node with key was found, or -1.
+func (s *SkipList[K, V]) find(key K, preds, succs *[maxLevel]*node[K, V]) int {
+	found := -1
+	pred := s.head
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && s.cmp(curr.key, key) < 0 {
+			pred, curr = curr, curr.next[level].Load()
+		}
+		if found == -1 && curr != nil && s.cmp(curr.key, key) == 0 {
+			found = level
+		}
+		preds[level], succs[level] = pred, curr
+	}
+	return found
+}
+
+// lookup returns the node holding key, whether or not it is live.
+func (s *SkipList[K, V]) lookup(key K) *node[K, V] {
+	pred := s.head
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && s.cmp(curr.key, key) < 0 {
+			pred, curr = curr, curr.next[level].Load()
+		}
+		if curr != nil && s.cmp(curr.key, key) == 0 {
+			return curr
+		}
+	}
+	return nil
+}
+
+// unlock releases the distinct predecessors locked on levels 0 to highest.
+func unlock[K, V any](preds *[maxLevel]*node[K, V], highest int) {
+	var prev *node[K, V]
+	for level := 0; level <= highest; level++ {
+		if preds[level] != prev {
+			preds[level].mu.Unlock()
+			prev = preds[level]
+		}
+	}
+}
+
+// Len returns the number of keys. It is exact only while no writer runs.
+func (s *SkipList[K, V]) Len() int {
+	return int(s.len.Load())
+}
+
+// Put associates value with key and reports whether key was already present.
+func (s *SkipList[K, V]) Put(key K, value V) bool {
+	var preds, succs [maxLevel]*node[K, V]
+	topLevel := randomLevel()
+	for {
+		if found := s.find(key, &preds, &succs); found != -1 {
+			n := succs[found]
+			n.mu.Lock()
+			if n.marked.Load() {
+				// being deleted, retry until it is unlinked
+				n.mu.Unlock()
+				continue
+			}
+			// a node is locked while it is linked, so holding the lock
+			// means it is fully linked
+			n.value.Store(&value)
+			n.mu.Unlock()
+			return true
+		}
+
+		// lock the predecessors bottom up and check nothing changed
+		// between them and their successors since find
+		highest := -1
+		valid := true
+		var prev *node[K, V]
+		for level := 0; valid && level < topLevel; level++ {
+			pred, succ := preds[level], succs[level]
+			if pred != prev {
+				pred.mu.Lock()
+				highest, prev = level, pred
+			}
+			valid = !pred.marked.Load() && (succ == nil || !succ.marked.Load()) &&
+				pred.next[level].Load() == succ
+		}
+		if !valid {
+			unlock(&preds, highest)
+			continue
+		}
+
+		n := &node[K, V]{key: key, next: make([]atomic.Pointer[node[K, V]], topLevel)}
+		n.value.Store(&value)
+		n.mu.Lock()
+		for level := 0; level < topLevel; level++ {
+			n.next[level].Store(succs[level])
+		}
+		for level := 0; level < topLevel; level++ {
+			preds[level].next[level].Store(n)
+		}
+		n.fullyLinked.Store(true)
+		n.mu.Unlock()
+		unlock(&preds, highest)
+		s.len.Add(1)
+		return false
+	}
+}
+
+// Get returns the value associated with key and whether it was found.
+// It never blocks.
+func (s *SkipList[K, V]) Get(key K) (V, bool) {
+	if n := s.lookup(key); n != nil && n.live() {
+		return *n.value.Load(), true
+	}
+	var v V
+	return v, false
+}
+
+// Contains reports whether key is present.
+func (s *SkipList[K, V]) Contains(key K) bool {
+	n := s.lookup(key)
+	return n != nil && n.live()
+}
+
+// Delete removes key and reports whether it was present.
+func (s *SkipList[K, V]) Delete(key K) bool {
+	var preds, succs [maxLevel]*node[K, V]
+	var victim *node[K, V]
+	for {
+		found := s.find(key, &preds, &succs)
+		if victim == nil {
+			if found == -1 {
+				return false
+			}
+			n := succs[found]
+			// a node found below its top level is still being linked
+			if !n.fullyLinked.Load() || n.topLevel()-1 != found {
+				return false
+			}
+			n.mu.Lock()
+			if n.marked.Load() {
+				n.mu.Unlock()
+				return false
+			}
+			// marking is the linearization point, the node is unlinked
+			// physically below while its lock is held
+			n.marked.Store(true)
+			victim = n
+		}
+
+		highest := -1
+		valid := true
+		var prev *node[K, V]
+		for level := 0; valid && level < victim.topLevel(); level++ {
+			pred := preds[level]
+			if pred != prev {
+				pred.mu.Lock()
+				highest, prev = level, pred
+			}
+			valid = !pred.marked.Load() && pred.next[level].Load() == victim
+		}
+		if !valid {
+			unlock(&preds, highest)
+			continue
+		}
+
+		for level := victim.topLevel() - 1; level >= 0; level-- {
+			preds[level].next[level].Store(victim.next[level].Load())
+		}
+		victim.mu.Unlock()
+		unlock(&preds, highest)
+		s.len.Add(-1)
+		return true
+	}
+}
+
+func unpack[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	return n.key, *n.value.Load(), true
+}
+
+// first returns the first live node from n on along the bottom level.
+func first[K, V any](n *node[K, V]) *node[K, V] {
+	for n != nil && !n.live() {
+		n = n.next[0].Load()
+	}
+	return n
+}
+
+// ceiling returns the first live node whose key is not less than key.
+func (s *SkipList[K, V]) ceiling(key K) *node[K, V] {
+	pred := s.head
+	for level := maxLevel - 1; level >= 0; level-- {
+		curr := pred.next[level].Load()
+		for curr != nil && s.cmp(curr.key, key) < 0 {
+			pred, curr = curr, curr.next[level].Load()
+		}
+	}
+	return first(pred.next[0].Load())
+}
+
+// floor returns the last live node whose key is not greater than key. A
+// node that is dead when reached is skipped by searching again below it.
+func (s *SkipList[K, V]) floor(key K, inclusive bool) *node[K, V] {
+	for {
+		pred := s.head
+		for level := maxLevel - 1; level >= 0; level-- {
+			curr := pred.next[level].Load()
+			for curr != nil {
+				if c := s.cmp(curr.key, key); c > 0 || (c == 0 && !inclusive) {
+					break
+				}
+				pred, curr = curr, curr.next[level].Load()
+			}
+		}
+		if pred == s.head {
+			return nil
+		}
+		if pred.live() {
+			return pred
+		}
+		key, inclusive = pred.key, false
+	}
+}
+
+// Min returns the smallest key and its value.
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	return unpack(first(s.head.next[0].Load()))
+}
+
+// Max returns the largest key and its value.
+func (s *SkipList[K, V]) Max() (K, V, bool) {
+	for {
+		x := s.head
+		for level := maxLevel - 1; level >= 0; level-- {
+			for next := x.next[level].Load(); next != nil; next = x.next[level].Load() {
+				x = next
+			}
+		}
+		if x == s.head {
+			return unpack[K, V](nil)
+		}
+		if x.live() {
+			return unpack(x)
+		}
+		if n := s.floor(x.key, false); n != nil {
+			return unpack(n)
+		}
+		// everything below x is gone, something may have been added after it
+	}
+}
+
+// Floor returns the greatest key less than or equal to key.
+func (s *SkipList[K, V]) Floor(key K) (K, V, bool) {
+	return unpack(s.floor(key, true))
+}
+
+// Ceiling returns the least key greater than or equal to key.
+func (s *SkipList[K, V]) Ceiling(key K) (K, V, bool) {
+	return unpack(s.ceiling(key))
+}
+
+// ascend yields the live nodes from n on until yield returns false or a key
+// reaches hi, if hi is not nil.
+func (s *SkipList[K, V]) ascend(n *node[K, V], hi *K, yield func(K, V) bool) {
+	for n = first(n); n != nil; n = first(n.next[0].Load()) {
+		if hi != nil && s.cmp(n.key, *hi) >= 0 {
+			return
+		}
+		if !yield(n.key, *n.value.Load()) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the pairs in ascending key order.
+func (s *SkipList[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.ascend(s.head.next[0].Load(), nil, yield)
+	}
+}
+
+// Ascend returns an iterator over the pairs whose key is not less than from.
+func (s *SkipList[K, V]) Ascend(from K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.ascend(s.ceiling(from), nil, yield)
+	}
+}
+
+// Range returns an iterator over the pairs whose key is in [lo, hi).
+func (s *SkipList[K, V]) Range(lo, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.ascend(s.ceiling(lo), &hi, yield)
+	}
+}
@@ -0,0 +1,296 @@
+package skiplist
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"golabs/container"
+	"golabs/container/containertest"
+	"golabs/container/rbtree"
+)
+
+var _ container.OrderedMap[int, int] = (*SkipList[int, int])(nil)
+
+func TestOrderedMap(t *testing.T) {
+	containertest.TestOrderedMap(t, func() container.OrderedMap[int, int] {
+		return New[int, int]()
+	})
+}
+
+func TestOrderedSet(t *testing.T) {
+	containertest.TestOrderedSet(t, func() container.OrderedSet[int] {
+		return container.SetOf(New[int, struct{}]())
+	})
+}
+
+func BenchmarkOrderedMap(b *testing.B) {
+	containertest.BenchmarkOrderedMap(b, 100000, func() container.OrderedMap[int, int] {
+		return New[int, int]()
+	})
+}
+
+// check verifies the structure once no writer runs: every level is sorted,
+// holds only live nodes and is a subsequence of the level below.
+func (s *SkipList[K, V]) check(t *testing.T) {
+	t.Helper()
+	var below map[*node[K, V]]bool
+	for level := 0; level < maxLevel; level++ {
+		seen := make(map[*node[K, V]]bool)
+		var prev *node[K, V]
+		for n := s.head.next[level].Load(); n != nil; n = n.next[level].Load() {
+			if !n.live() {
+				t.Fatalf("level %d holds a dead node %v", level, n.key)
+			}
+			if prev != nil && s.cmp(prev.key, n.key) >= 0 {
+				t.Fatalf("level %d: %v before %v", level, prev.key, n.key)
+			}
+			if below != nil && !below[n] {
+				t.Fatalf("level %d: %v is missing below", level, n.key)
+			}
+			seen[n] = true
+			prev = n
+		}
+		if level == 0 && len(seen) != s.Len() {
+			t.Fatalf("Len() = %d; bottom level holds %d", s.Len(), len(seen))
+		}
+		below = seen
+	}
+}
+
+func TestRange(t *testing.T) {
+	s := New[int, string]()
+	for _, k := range []int{1, 3, 5, 7, 9} {
+		s.Put(k, fmt.Sprint(k))
+	}
+	var got []int
+	for k, v := range s.Range(3, 9) {
+		if v != fmt.Sprint(k) {
+			t.Errorf("Range yielded %d: %q", k, v)
+		}
+		got = append(got, k)
+	}
+	if !slices.Equal(got, []int{3, 5, 7}) {
+		t.Errorf("Range(3, 9) = %v; want [3 5 7]", got)
+	}
+	if k, _, ok := s.Floor(6); !ok || k != 5 {
+		t.Errorf("Floor(6) = %d, %v; want 5, true", k, ok)
+	}
+	if k, _, ok := s.Ceiling(6); !ok || k != 7 {
+		t.Errorf("Ceiling(6) = %d, %v; want 7, true", k, ok)
+	}
+	s.check(t)
+}
+
+// TestParallelOwned gives every writer its own keys, so each one knows
+// exactly what its operations must return, while readers check that scans
+// and queries stay ordered. Run it with -race.
+func TestParallelOwned(t *testing.T) {
+	const writers, keys = 8, 4096
+	ops := 20000
+	if testing.Short() {
+		ops = 2000
+	}
+	s := New[int, int]()
+	refs := make([]map[int]int, writers)
+	done := make(chan struct{})
+	var wg, readers sync.WaitGroup
+
+	for w := 0; w < writers; w++ {
+		refs[w] = make(map[int]int)
+		wg.Add(1)
+		go func(w int, ref map[int]int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < ops; i++ {
+				k := r.Intn(keys/writers)*writers + w
+				_, want := ref[k]
+				switch r.Intn(3) {
+				case 0:
+					if got := s.Delete(k); got != want {
+						t.Errorf("Delete(%d) = %v; want %v", k, got, want)
+						return
+					}
+					delete(ref, k)
+				case 1:
+					if got := s.Put(k, i); got != want {
+						t.Errorf("Put(%d) = %v; want %v", k, got, want)
+						return
+					}
+					ref[k] = i
+				default:
+					if v, ok := s.Get(k); ok != want || v != ref[k] {
+						t.Errorf("Get(%d) = %d, %v; want %d, %v", k, v, ok, ref[k], want)
+						return
+					}
+				}
+			}
+		}(w, refs[w])
+	}
+
+	for r := 0; r < 2; r++ {
+		readers.Add(1)
+		go func(seed int64) {
+			defer readers.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				prev := -1
+				for k := range s.Ascend(rnd.Intn(keys)) {
+					if k <= prev {
+						t.Errorf("Ascend yielded %d after %d", k, prev)
+						return
+					}
+					if prev = k; rnd.Intn(64) == 0 {
+						break
+					}
+				}
+				q := rnd.Intn(keys)
+				if k, _, ok := s.Floor(q); ok && k > q {
+					t.Errorf("Floor(%d) = %d", q, k)
+				}
+				if k, _, ok := s.Ceiling(q); ok && k < q {
+					t.Errorf("Ceiling(%d) = %d", q, k)
+				}
+			}
+		}(int64(r))
+	}
+
+	wg.Wait()
+	close(done)
+	readers.Wait()
+
+	s.check(t)
+	want := make(map[int]int)
+	for _, ref := range refs {
+		maps.Copy(want, ref)
+	}
+	if got := maps.Collect(s.All()); !maps.Equal(got, want) {
+		t.Errorf("All() differs from the writers' records: %d keys; want %d", len(got), len(want))
+	}
+}
+
+// TestParallelContended has every goroutine fight over a few keys. For each
+// key the successful inserts and deletes must alternate, so they differ by
+// at most one, and by exactly one if the key is left in the list.
+func TestParallelContended(t *testing.T) {
+	const workers, keys = 8, 16
+	ops := 20000
+	if testing.Short() {
+		ops = 2000
+	}
+	s := New[int, int]()
+	var inserted, deleted [keys]atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			for i := 0; i < ops; i++ {
+				k := r.Intn(keys)
+				if r.Intn(2) == 0 {
+					if !s.Put(k, w) {
+						inserted[k].Add(1)
+					}
+				} else if s.Delete(k) {
+					deleted[k].Add(1)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	s.check(t)
+	for k := 0; k < keys; k++ {
+		diff := inserted[k].Load() - deleted[k].Load()
+		if want := int64(btoi(s.Contains(k))); diff != want {
+			t.Errorf("key %d: %d inserts and %d deletes, Contains() = %v",
+				k, inserted[k].Load(), deleted[k].Load(), want == 1)
+		}
+	}
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// lockedMap is the baseline the skip list has to beat: a red-black tree
+// behind one lock.
+type lockedMap struct {
+	mu   sync.RWMutex
+	tree *rbtree.TreeMap[int, int]
+}
+
+func (m *lockedMap) Get(key int) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.tree.Get(key)
+}
+
+func (m *lockedMap) Put(key, value int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tree.Put(key, value)
+}
+
+func (m *lockedMap) Delete(key int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tree.Delete(key)
+}
+
+type concurrentMap interface {
+	Get(key int) (int, bool)
+	Put(key, value int) bool
+	Delete(key int) bool
+}
+
+func BenchmarkParallel(b *testing.B) {
+	const n = 1 << 16
+	impls := []struct {
+		name string
+		new  func() concurrentMap
+	}{
+		{"SkipList", func() concurrentMap { return New[int, int]() }},
+		{"LockedRBTree", func() concurrentMap { return &lockedMap{tree: rbtree.NewTreeMap[int, int]()} }},
+	}
+	// writes is the percentage of operations that are Put or Delete
+	for _, writes := range []int{0, 10, 50, 100} {
+		for _, impl := range impls {
+			b.Run(fmt.Sprintf("Writes%d/%s", writes, impl.name), func(b *testing.B) {
+				m := impl.new()
+				for k := 0; k < n; k += 2 {
+					m.Put(k, k)
+				}
+				var seed atomic.Int64
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					r := rand.New(rand.NewSource(seed.Add(1)))
+					for pb.Next() {
+						k := r.Intn(n)
+						switch op := r.Intn(100); {
+						case op >= writes:
+							m.Get(k)
+						case op%2 == 0:
+							m.Put(k, op)
+						default:
+							m.Delete(k)
+						}
+					}
+				})
+			})
+		}
+	}
+}